@@ -0,0 +1,248 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// ExitCodeError is the exit status of a CLI if the configuration is invalid or a command fails.
+	ExitCodeError = 1
+
+	// ExitCodeUsage is the exit status of a CLI if it's called with an unknown command or invalid flags.
+	ExitCodeUsage = 2
+
+	// CommandConfigValidate is the name of the built-in command that validates the configuration.
+	CommandConfigValidate = "config validate"
+
+	// CommandHelp is the name of the built-in command that prints the usage.
+	CommandHelp = "help"
+
+	// CommandVersion is the name of the built-in command that prints the version.
+	CommandVersion = "version"
+)
+
+// CommandFunc runs a command with the arguments remaining after the command name and flags.
+// The configuration passed to NewCLI is loaded before it's called.
+type CommandFunc func(ctx context.Context, args []string) error
+
+// Command is a subcommand of a CLI.
+type Command struct {
+	// Name is the name of the command. It may consist of multiple words, e.g. "config validate".
+	Name string
+
+	// Description is a short summary shown in the usage.
+	Description string
+
+	// Run is called with the remaining arguments after the configuration was loaded.
+	Run CommandFunc
+
+	// SkipConfig prevents loading the configuration before Run is called, e.g. for printing the version.
+	SkipConfig bool
+}
+
+// CLI dispatches the command-line arguments to subcommands, e.g. `app serve --http.port=8080`.
+// Before a command is run, the configuration is loaded with a flag set for that command, so every command
+// accepts the configuration flags. The commands "config validate", "version" and "help" are built in.
+type CLI struct {
+	// Output receives the usage, version and messages of the built-in commands.
+	Output io.Writer
+
+	// DefaultCommand is run if no command is given. If it's empty, the usage is printed instead.
+	DefaultCommand string
+
+	config   Configure
+	opts     []Option
+	commands []*Command
+}
+
+// NewCLI creates a CLI that loads the given configuration with the options before running a command.
+func NewCLI(config Configure, opts ...Option) *CLI {
+	cli := &CLI{
+		Output: os.Stderr,
+		config: config,
+		opts:   opts,
+	}
+
+	cli.Handle(&Command{
+		Name:        CommandConfigValidate,
+		Description: "validate the configuration and exit",
+		Run:         cli.validateConfig,
+	})
+	cli.Handle(&Command{
+		Name:        CommandVersion,
+		Description: "print the version and exit",
+		Run:         cli.printVersion,
+		SkipConfig:  true,
+	})
+	cli.Handle(&Command{
+		Name:        CommandHelp,
+		Description: "print this help and exit",
+		Run:         cli.printHelp,
+		SkipConfig:  true,
+	})
+
+	return cli
+}
+
+// Handle registers a command. It replaces a registered command with the same name, including built-in ones.
+func (r *CLI) Handle(command *Command) *CLI {
+	r.commands = slices.DeleteFunc(r.commands, func(registered *Command) bool {
+		return registered.Name == command.Name
+	})
+	r.commands = append(r.commands, command)
+
+	return r
+}
+
+// HandleFunc registers a command with the given name, description and function.
+func (r *CLI) HandleFunc(name, description string, run CommandFunc) *CLI {
+	return r.Handle(&Command{Name: name, Description: description, Run: run})
+}
+
+// Execute runs the command given by os.Args and exits with its exit status.
+func (r *CLI) Execute(ctx context.Context) {
+	OsExit(r.Run(ctx, os.Args[1:]))
+}
+
+// Run looks up the command given by the arguments, loads the configuration with the remaining arguments as
+// flags, and runs the command. It returns the exit status, which is ExitCodeError if loading the configuration
+// or the command fails, and ExitCodeUsage for an unknown command.
+func (r *CLI) Run(ctx context.Context, args []string) int {
+	command, args := r.lookup(args)
+	if command == nil {
+		if len(args) > 0 {
+			_, _ = fmt.Fprintf(r.Output, "unknown command %q\n\n", strings.Join(args, " "))
+		}
+		r.Usage()
+
+		return ExitCodeUsage
+	}
+
+	if !command.SkipConfig {
+		cfg, flagSet, err := r.newConfig(command, args)
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, ErrInvalidFlags):
+			return ExitCodeUsage
+		case err != nil:
+			_, _ = fmt.Fprintln(r.Output, err)
+
+			return ExitCodeError
+		}
+
+		if err = cfg.load(r.config); err != nil {
+			_, _ = fmt.Fprintf(r.Output, "invalid configuration:\n%s\n", err)
+
+			return ExitCodeError
+		}
+		args = flagSet.Args()
+	}
+
+	if err := command.Run(ctx, args); err != nil {
+		_, _ = fmt.Fprintf(r.Output, "%s: %s\n", command.Name, err)
+
+		return ExitCodeError
+	}
+
+	return 0
+}
+
+// Usage writes the usage with the application's metadata and the available commands to the Output.
+func (r *CLI) Usage() {
+	metadata := r.metadata()
+
+	_, _ = fmt.Fprintf(r.Output, "Usage: %s <command> [flags] [arguments]\n\n", metadata.Slug)
+	writeMetadata(r.Output, metadata)
+
+	_, _ = fmt.Fprintln(r.Output, "Commands:")
+	writer := tabwriter.NewWriter(r.Output, 0, 0, 2, ' ', 0) //nolint:mnd // Padding between columns.
+	for _, command := range r.commands {
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\n", command.Name, command.Description)
+	}
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(r.Output, "\nRun '%s <command> -h' to list the flags of a command.\n", metadata.Slug)
+}
+
+// lookup returns the command with the longest name matching the leading arguments and the remaining arguments.
+// Without arguments, the DefaultCommand is returned.
+func (r *CLI) lookup(args []string) (*Command, []string) {
+	if len(args) == 0 && r.DefaultCommand != "" {
+		args = strings.Fields(r.DefaultCommand)
+	}
+
+	var (
+		found     *Command
+		foundSize int
+	)
+	for _, command := range r.commands {
+		words := strings.Fields(command.Name)
+		if len(words) > foundSize && len(words) <= len(args) && slices.Equal(words, args[:len(words)]) {
+			found, foundSize = command, len(words)
+		}
+	}
+
+	if found == nil {
+		return nil, args
+	}
+
+	return found, args[foundSize:]
+}
+
+// newConfig creates the Config for the command, which parses the arguments with a flag set for the command.
+// The flag set writes parsing errors and its usage to the Output.
+func (r *CLI) newConfig(command *Command, args []string) (*Config, *flag.FlagSet, error) {
+	flagSet := flag.NewFlagSet(r.metadata().Slug+" "+command.Name, flag.ContinueOnError)
+	flagSet.SetOutput(r.Output)
+
+	cfg, err := newConfig(r.config, append(slices.Clone(r.opts), WithFlagSet(flagSet, args))...)
+
+	return cfg, flagSet, err
+}
+
+// metadata returns the application's metadata given by the options or the configuration.
+func (r *CLI) metadata() *Metadata {
+	cfg, err := applyOptions(r.config, r.opts...)
+	if err != nil {
+		return &Metadata{}
+	}
+
+	return cfg.Metadata
+}
+
+// validateConfig is the built-in "config validate" command. It's only called if the configuration is valid.
+func (r *CLI) validateConfig(_ context.Context, _ []string) error {
+	_, err := fmt.Fprintln(r.Output, "configuration is valid")
+
+	return err
+}
+
+// printVersion is the built-in "version" command.
+func (r *CLI) printVersion(_ context.Context, _ []string) error {
+	metadata := r.metadata()
+
+	version := metadata.Version
+	if version == "" {
+		version = "unknown"
+	}
+
+	_, err := fmt.Fprintf(r.Output, "%s %s\n", metadata.AppName, version)
+
+	return err
+}
+
+// printHelp is the built-in "help" command.
+func (r *CLI) printHelp(_ context.Context, _ []string) error {
+	r.Usage()
+
+	return nil
+}
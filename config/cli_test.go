@@ -0,0 +1,180 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errMigration = errors.New("migration failed")
+
+func newTestCLI(t *testing.T, config Configure, output *bytes.Buffer) (*CLI, *[]string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	var called []string
+	cli := NewCLI(config, WithMetadata(&Metadata{
+		AppName:     "Test App",
+		Slug:        "test-app",
+		Version:     "1.2.3",
+		Description: "an application for tests",
+	}))
+	cli.Output = output
+	cli.HandleFunc("serve", "start the server", func(_ context.Context, args []string) error {
+		called = append(called, "serve")
+		called = append(called, args...)
+		return nil
+	})
+	cli.HandleFunc("migrate", "migrate the database", func(_ context.Context, _ []string) error {
+		return errMigration
+	})
+	cli.HandleFunc("migrate down", "revert the last migration", func(_ context.Context, _ []string) error {
+		called = append(called, "migrate down")
+		return nil
+	})
+
+	return cli, &called
+}
+
+func TestCLI_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		config     Configure
+		setup      func(cli *CLI)
+		wantCode   int
+		wantCalled []string
+		wantOutput []string
+	}{
+		{
+			name:       "command with flags and arguments",
+			args:       []string{"serve", "--port=9090", "extra"},
+			wantCalled: []string{"serve", "extra"},
+		},
+		{
+			name:       "command with multiple words",
+			args:       []string{"migrate", "down"},
+			wantCalled: []string{"migrate down"},
+		},
+		{
+			name:       "failing command",
+			args:       []string{"migrate"},
+			wantCode:   ExitCodeError,
+			wantOutput: []string{"migrate: migration failed"},
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"deploy"},
+			wantCode:   ExitCodeUsage,
+			wantOutput: []string{`unknown command "deploy"`, "Usage: test-app <command>", "  serve"},
+		},
+		{
+			name:       "no command",
+			wantCode:   ExitCodeUsage,
+			wantOutput: []string{"Description: an application for tests", "  config validate  validate the configuration"},
+		},
+		{
+			name:       "default command",
+			setup:      func(cli *CLI) { cli.DefaultCommand = "serve" },
+			wantCalled: []string{"serve"},
+		},
+		{
+			name:       "invalid flag",
+			args:       []string{"serve", "--port=invalid"},
+			wantCode:   ExitCodeUsage,
+			wantOutput: []string{`invalid value "invalid" for flag -port`},
+		},
+		{
+			name:       "help flag",
+			args:       []string{"serve", "-h"},
+			wantOutput: []string{"Usage of Test App:", "-port value"},
+		},
+		{
+			name:       "version",
+			args:       []string{"version"},
+			wantOutput: []string{"Test App 1.2.3"},
+		},
+		{
+			name:       "help",
+			args:       []string{"help"},
+			wantOutput: []string{"Commands:"},
+		},
+		{
+			name:       "config validate",
+			args:       []string{"config", "validate"},
+			wantOutput: []string{"configuration is valid"},
+		},
+		{
+			name:       "config validate with invalid configuration",
+			args:       []string{"config", "validate", "--host=", "--tags="},
+			config:     &nestedConfig{},
+			wantCode:   ExitCodeError,
+			wantOutput: []string{"invalid configuration:\n", "tags: length must be at least 1\n", "host: must be set\n"},
+		},
+		{
+			name:       "invalid configuration prevents commands",
+			args:       []string{"serve", "--config=missing.yaml"},
+			wantCode:   ExitCodeError,
+			wantOutput: []string{"invalid configuration:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config == nil {
+				config = &mockConfig{}
+			}
+
+			output := &bytes.Buffer{}
+			cli, called := newTestCLI(t, config, output)
+			if tt.setup != nil {
+				tt.setup(cli)
+			}
+
+			assert.Equal(t, tt.wantCode, cli.Run(context.Background(), tt.args))
+			assert.Equal(t, tt.wantCalled, *called)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, output.String(), want)
+			}
+		})
+	}
+}
+
+func TestCLI_RunLoadsConfig(t *testing.T) {
+	output := &bytes.Buffer{}
+	config := &mockConfig{}
+	cli, _ := newTestCLI(t, config, output)
+	require.NoError(t, os.WriteFile(filepath.Join(".", "config.yaml"), []byte("host: example.com\n"), 0o600))
+
+	var port int
+	cli.HandleFunc("serve", "start the server", func(_ context.Context, _ []string) error {
+		port = config.Port
+		return nil
+	})
+
+	require.Equal(t, 0, cli.Run(context.Background(), []string{"serve", "--config=config.yaml", "--port=9090"}))
+	assert.Equal(t, 9090, port)
+	assert.Equal(t, "example.com", config.Host)
+}
+
+func TestCLI_Execute(t *testing.T) {
+	exitCode := -1
+	OsExit = func(code int) {
+		exitCode = code
+	}
+	t.Cleanup(func() {
+		OsExit = os.Exit
+	})
+
+	cli, _ := newTestCLI(t, &mockConfig{}, &bytes.Buffer{})
+	cli.Execute(context.Background())
+
+	assert.NotEqual(t, -1, exitCode)
+}
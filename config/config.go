@@ -1,27 +1,61 @@
 package config
 
 import (
+	"flag"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spacecafe/gobox/logger"
 )
 
 // Config contain configuration settings, including logging, metadata, environment, YAML, and file loading preferences.
 type Config struct {
-	Log              logger.Logger
-	ConfigFilePath   string
-	EnvPrefix        string
-	Metadata         *Metadata
-	EnvAliases       Aliases
-	EnvFileLoading   bool
-	Flags            bool
-	YAMLEnvExpansion bool
-	YAMLFileLoading  bool
+	Log                 logger.Logger
+	ConfigFilePath      string
+	ConfigFiles         []string
+	EnvPrefix           string
+	Metadata            *Metadata
+	EnvAliases          Aliases
+	Decoders            Decoders
+	TagResolvers        TagResolvers
+	DotEnvFiles         []string
+	EnvironmentVariable string
+	FlagSet             *flag.FlagSet
+	Args                []string
+	Sources             Sources
+	ConfigSchema        string
+	Output              io.Writer
+	WatchInterval       time.Duration
+	LayeredFiles        bool
+	EnvFileLoading      bool
+	Flags               bool
+	PrintConfig         bool
+	Strict              bool
+	YAMLEnvExpansion    bool
+	YAMLFileLoading     bool
+
+	// fieldFlags contains the flags registered for the fields of the configuration.
+	fieldFlags []*fieldFlag
+
+	// currentFile is the path of the configuration file being decoded, used to record the Sources.
+	currentFile string
 }
 
 type Aliases map[string]string
 
+const (
+	// ConfigFragmentsDir is the directory next to a configuration file that contains additional fragments.
+	ConfigFragmentsDir = "conf.d"
+
+	// DefaultEnvironmentVariable is the variable that selects the environment overlay in layered mode.
+	DefaultEnvironmentVariable = "ENVIRONMENT"
+
+	// DefaultWatchInterval is the interval in which a Watcher checks the configuration files for changes.
+	DefaultWatchInterval = 2 * time.Second
+)
+
 // SetDefaults initializes the Config with default values for metadata, logger, and configuration options.
 func (r *Config) SetDefaults() {
 	name := filepath.Base(os.Args[0])
@@ -35,7 +69,19 @@ func (r *Config) SetDefaults() {
 		Slug:    name,
 	}
 	r.EnvAliases = make(Aliases)
+	r.Decoders = DefaultDecoders()
+	r.TagResolvers = DefaultTagResolvers()
+	r.DotEnvFiles = nil
+	r.EnvironmentVariable = ""
+	r.LayeredFiles = false
+	r.WatchInterval = DefaultWatchInterval
 	r.Flags = false
+	r.FlagSet = flag.CommandLine
+	r.Args = os.Args[1:]
+	r.Output = os.Stdout
+	r.PrintConfig = false
+	r.ConfigSchema = ""
+	r.Strict = false
 	r.YAMLEnvExpansion = false
 	r.YAMLFileLoading = false
 	r.EnvFileLoading = false
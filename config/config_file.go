@@ -1,41 +1,170 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // lookupConfigFile searches for a configuration file in common directories and updates Config with its path.
 // It checks user configuration, system directories, and local directories using predefined file name patterns.
 func (r *Config) lookupConfigFile() error {
+	filePath, ok := r.findMainConfigFile()
+	if !ok {
+		return ErrConfigFileNotFound
+	}
+
+	r.ConfigFilePath = filePath
+
+	return nil
+}
+
+// lookupConfigFiles returns the configuration files the next load would read, without changing the Config.
+// It picks up files that were created since the last load, e.g. new `conf.d` fragments or environment overlays.
+func (r *Config) lookupConfigFiles() []string {
+	if r.LayeredFiles {
+		return r.lookupLayeredConfigFiles()
+	}
+
+	if r.ConfigFilePath != "" {
+		return []string{r.ConfigFilePath}
+	}
+
+	if filePath, ok := r.findMainConfigFile(); ok {
+		return []string{filePath}
+	}
+
+	return nil
+}
+
+// lookupLayeredConfigFiles collects all configuration files of the layered mode, ordered from the lowest to the
+// highest precedence: the main files of the system, user, and local directories, their `conf.d` fragments in lexical
+// order, and finally the overlays of the current environment, e.g. `config.production.yaml`.
+// If a configuration file path is set, its directory is the only layer and the file itself is the main file.
+func (r *Config) lookupLayeredConfigFiles() []string {
+	var (
+		dirs  []string
+		files []string
+	)
+
+	if r.ConfigFilePath != "" {
+		dirs = []string{filepath.Dir(r.ConfigFilePath)}
+		files = append(files, r.ConfigFilePath)
+	} else {
+		dirs = append(dirs, filepath.Join(SysConfigDir(), r.Metadata.Slug))
+		if dir, err := os.UserConfigDir(); err == nil {
+			dirs = append(dirs, filepath.Join(dir, r.Metadata.Slug))
+		}
+		dirs = append(dirs, ".")
+
+		for _, dir := range dirs {
+			if filePath, ok := r.findConfigFile(dir, ""); ok {
+				files = append(files, filePath)
+			}
+		}
+	}
+
+	for _, dir := range dirs {
+		files = append(files, r.findConfigFragments(filepath.Join(dir, ConfigFragmentsDir))...)
+	}
+
+	environment := r.environment()
+	if environment == "" {
+		return files
+	}
+
+	if r.ConfigFilePath != "" {
+		ext := filepath.Ext(r.ConfigFilePath)
+		overlay := strings.TrimSuffix(r.ConfigFilePath, ext) + "." + environment + ext
+		if _, err := os.Stat(overlay); err == nil {
+			files = append(files, overlay)
+		}
+
+		return files
+	}
+
+	for _, dir := range dirs {
+		if filePath, ok := r.findConfigFile(dir, "."+environment); ok {
+			files = append(files, filePath)
+		}
+	}
+
+	return files
+}
+
+// findConfigFile returns the first existing configuration file within the directory.
+// The file name consists of the application's slug or "config", the given suffix, and a registered extension.
+func (r *Config) findConfigFile(dir, suffix string) (string, bool) {
+	for _, name := range []string{r.Metadata.Slug, "config"} {
+		for _, ext := range r.Decoders.Extensions() {
+			filePath := filepath.Join(dir, name+suffix+ext)
+			if _, err := os.Stat(filePath); err == nil {
+				return filePath, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// findMainConfigFile returns the first existing configuration file within the local, user, and system directories.
+func (r *Config) findMainConfigFile() (string, bool) {
 	paths := []string{"."}
 	if dir, err := os.UserConfigDir(); err == nil {
 		paths = append(paths, filepath.Join(dir, r.Metadata.Slug))
 	}
 	paths = append(paths, filepath.Join(SysConfigDir(), r.Metadata.Slug))
 
-	fileNames := []string{
-		r.Metadata.Slug + ".yml",
-		r.Metadata.Slug + ".yaml",
-		"config.yml",
-		"config.yaml",
+	for _, path := range paths {
+		if filePath, ok := r.findConfigFile(path, ""); ok {
+			return filePath, true
+		}
 	}
 
-	for _, path := range paths {
-		for _, name := range fileNames {
-			filePath := filepath.Join(path, name)
-			if _, err := os.Stat(filePath); err == nil {
-				r.ConfigFilePath = filePath
-				return nil
-			}
+	return "", false
+}
+
+// findConfigFragments returns all files with a registered extension within the directory in lexical order.
+func (r *Config) findConfigFragments(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, ok := r.Decoders.Lookup(entry.Name()); ok {
+			files = append(files, filepath.Join(dir, entry.Name()))
 		}
 	}
 
-	return ErrConfigFileNotFound
+	return files
+}
+
+// environment returns the name of the current environment used to select configuration overlays.
+func (r *Config) environment() string {
+	return strings.TrimSpace(os.Getenv(r.environmentVariable()))
+}
+
+// environmentVariable returns the name of the variable that selects the environment.
+// Unless an explicit variable is configured, it's DefaultEnvironmentVariable including the EnvPrefix.
+func (r *Config) environmentVariable() string {
+	if r.EnvironmentVariable != "" {
+		return r.EnvironmentVariable
+	}
+
+	if r.EnvPrefix != "" {
+		return r.EnvPrefix + "_" + DefaultEnvironmentVariable
+	}
+
+	return DefaultEnvironmentVariable
 }
 
 // loadConfigFile reads the configuration file specified in the Config and returns its content as a byte slice.
-// Expands environment variables in YAML content if WithYAMLEnvExpansion is enabled in the Config.
 func (r *Config) loadConfigFile() ([]byte, error) {
 	if r.ConfigFilePath == "" {
 		if err := r.lookupConfigFile(); err != nil {
@@ -43,7 +172,32 @@ func (r *Config) loadConfigFile() ([]byte, error) {
 		}
 	}
 
-	data, err := os.ReadFile(r.ConfigFilePath)
+	return r.readConfigFile(r.ConfigFilePath)
+}
+
+// loadLayeredConfigFiles decodes all configuration files of the layered mode one after another into the Configure
+// object. Later files override the values of earlier ones, while nested structs and maps are merged key by key.
+func (r *Config) loadLayeredConfigFiles(config Configure) error {
+	r.ConfigFiles = r.lookupLayeredConfigFiles()
+
+	for _, filePath := range r.ConfigFiles {
+		data, err := r.readConfigFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		if err = r.decodeConfigFile(config, filePath, data); err != nil {
+			return fmt.Errorf("%s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// readConfigFile reads a configuration file and returns its content as a byte slice.
+// Expands environment variables in the content if WithYAMLEnvExpansion is enabled in the Config.
+func (r *Config) readConfigFile(filePath string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Clean(filePath))
 	if err != nil {
 		return []byte{}, err
 	}
@@ -54,3 +208,19 @@ func (r *Config) loadConfigFile() ([]byte, error) {
 
 	return data, nil
 }
+
+// decodeConfigFile decodes the content of a configuration file with the decoder registered for its extension.
+// Files with an unknown extension are decoded as YAML.
+func (r *Config) decodeConfigFile(config Configure, filePath string, data []byte) error {
+	decoder, ok := r.Decoders.Lookup(filePath)
+	if !ok {
+		decoder = LoadFromYAML
+	}
+
+	r.currentFile = filePath
+	defer func() {
+		r.currentFile = ""
+	}()
+
+	return decoder(r, config, data)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FileDecoder decodes the content of a configuration file into a Configure object.
+type FileDecoder func(cfg *Config, config Configure, data []byte) error
+
+// Decoders maps file extensions, including the leading dot, to the FileDecoder responsible for them.
+type Decoders map[string]FileDecoder
+
+// defaultExtensions defines the order in which the built-in file extensions are searched for.
+//
+//nolint:gochecknoglobals // This is a lookup list that cannot be declared as a constant.
+var defaultExtensions = []string{".yml", ".yaml", ".json", ".toml"}
+
+// DefaultDecoders returns the built-in decoders for YAML, JSON and TOML files.
+func DefaultDecoders() Decoders {
+	return Decoders{
+		".yml":  LoadFromYAML,
+		".yaml": LoadFromYAML,
+		".json": LoadFromJSON,
+		".toml": LoadFromTOML,
+	}
+}
+
+// Extensions returns all registered file extensions.
+// Built-in extensions come first, followed by custom extensions in lexical order.
+func (r Decoders) Extensions() []string {
+	extensions := make([]string, 0, len(r))
+	for _, ext := range defaultExtensions {
+		if _, ok := r[ext]; ok {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	custom := make([]string, 0, len(r))
+	for ext := range r {
+		if !slices.Contains(defaultExtensions, ext) {
+			custom = append(custom, ext)
+		}
+	}
+	slices.Sort(custom)
+
+	return append(extensions, custom...)
+}
+
+// Lookup returns the FileDecoder registered for the extension of the given file path.
+func (r Decoders) Lookup(filePath string) (FileDecoder, bool) {
+	decoder, ok := r[strings.ToLower(filepath.Ext(filePath))]
+	return decoder, ok
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultDotEnvFile is the file loaded by WithDotEnv if no paths are given.
+const DefaultDotEnvFile = ".env"
+
+// LoadDotEnv reads `KEY=value` pairs from the given dotenv files and adds them to the process environment.
+// Variables that are already set are never overridden, and files that do not exist are skipped.
+func LoadDotEnv(paths ...string) error {
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		variables, err := parseDotEnv(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, variable := range variables {
+			if _, ok := os.LookupEnv(variable[0]); ok {
+				continue
+			}
+			if err = os.Setenv(variable[0], variable[1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseDotEnv parses the content of a dotenv file into an ordered list of name and value pairs.
+// It supports comments, an optional `export` prefix, and single- or double-quoted values.
+func parseDotEnv(data []byte) ([][2]string, error) {
+	var variables [][2]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, ErrInvalidDotEnvLine)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		variables = append(variables, [2]string{name, value})
+	}
+
+	return variables, scanner.Err()
+}
+
+// parseDotEnvValue removes quotes and inline comments from a dotenv value.
+// A quoted value ends at its matching closing quote, which may only be followed by a ` # comment`.
+// Escape sequences are only interpreted within double quotes.
+func parseDotEnvValue(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) && !strings.HasPrefix(value, "'") {
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	end := closingQuoteIndex(value)
+	if end < 0 {
+		return "", ErrInvalidDotEnvLine
+	}
+
+	rest := value[end+1:]
+	if comment := strings.TrimLeft(rest, " \t"); comment != "" && (comment == rest || comment[0] != '#') {
+		return "", ErrInvalidDotEnvLine
+	}
+
+	if value[0] == '"' {
+		return strconv.Unquote(value[:end+1])
+	}
+	return value[1:end], nil
+}
+
+// closingQuoteIndex returns the index of the quote closing the quote at the start of the value, or -1 if it is
+// missing. Escaped quotes are skipped within double quotes.
+func closingQuoteIndex(value string) int {
+	quote := value[0]
+	for i := 1; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && quote == '"':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -1,12 +1,28 @@
 package config
 
 import (
+	"encoding"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// EnvListSeparator separates the elements of slices and the entries of maps in environment variables.
+	EnvListSeparator = ","
+
+	// EnvMapSeparator separates the entries of maps whose values are slices in environment variables.
+	EnvMapSeparator = ";"
+)
+
+//nolint:gochecknoglobals // These are type lookups used by reflection and cannot be declared as constants.
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
 )
 
 type EnvAliasesProvider interface {
@@ -18,15 +34,8 @@ type envDecoder struct {
 	mappings []fieldMapping
 }
 
-// fieldMapping represents a mapping between YAML path and struct field.
-type fieldMapping struct {
-	EnvName   string
-	EnvAlias  string
-	FieldPath []int
-	FieldType reflect.Type
-}
-
 // LoadFromEnv populates a configuration struct using environment variables based on field mappings and provided context.
+// In strict mode, variables with the EnvPrefix that don't match a field or alias are rejected.
 func LoadFromEnv(cfg *Config, config Configure) error {
 	decoder := envDecoder{
 		cfg:      cfg,
@@ -36,24 +45,46 @@ func LoadFromEnv(cfg *Config, config Configure) error {
 	decoder.extractFieldMappings(rootValue.Type(), []string{}, []int{})
 
 	for _, mapping := range decoder.mappings {
-		envValue, ok := decoder.getEnvValue(mapping.EnvName)
+		envValue, source, ok := decoder.lookupEnvValue(mapping)
 		if !ok {
-			if mapping.EnvAlias != "" {
-				if envValue, ok = decoder.getEnvValue(mapping.EnvAlias); !ok {
-					continue
-				}
-			} else {
-				continue
-			}
+			continue
 		}
 
 		if err := decoder.setFieldValue(rootValue, mapping, envValue); err != nil {
-			return fmt.Errorf("error setting %s: %w", mapping.EnvName, err)
+			return fmt.Errorf("error setting %s: %w", source.Name, err)
 		}
+		cfg.Sources.set(mapping.YAMLPath, source)
 	}
+
+	if cfg.Strict {
+		return cfg.checkUnknownEnv(decoder.mappings)
+	}
+
 	return nil
 }
 
+// lookupEnvValue retrieves the value of a field mapping from its environment variable or alias
+// and returns the Source describing which variable provided it.
+func (r *envDecoder) lookupEnvValue(mapping fieldMapping) (string, Source, bool) {
+	if value, name, ok := r.getEnvValue(mapping.EnvName); ok {
+		if name != mapping.EnvName {
+			return value, Source{Kind: SourceEnvFile, Name: name}, true
+		}
+		return value, Source{Kind: SourceEnv, Name: name}, true
+	}
+
+	if mapping.EnvAlias != "" {
+		if value, name, ok := r.getEnvValue(mapping.EnvAlias); ok {
+			if name != mapping.EnvAlias {
+				return value, Source{Kind: SourceEnvFile, Name: name}, true
+			}
+			return value, Source{Kind: SourceEnvAlias, Name: name}, true
+		}
+	}
+
+	return "", Source{}, false
+}
+
 // extractFieldMappings recursively processes struct fields.
 func (r *envDecoder) extractFieldMappings(t reflect.Type, yamlPath []string, fieldPath []int) {
 	for i := 0; i < t.NumField(); i++ {
@@ -68,9 +99,12 @@ func (r *envDecoder) extractFieldMappings(t reflect.Type, yamlPath []string, fie
 			continue
 		}
 
-		yamlName := strings.Split(yamlTag, ",")[0]
-		currentYAMLPath := append(yamlPath, yamlName) //nolint:gocritic
-		currentFieldPath := append(fieldPath, i)      //nolint:gocritic
+		// Inlined fields have no key of their own, so their fields belong to the current path.
+		currentYAMLPath := yamlPath
+		if name := yamlName(field); name != "" {
+			currentYAMLPath = append(yamlPath, name) //nolint:gocritic
+		}
+		currentFieldPath := append(fieldPath, i) //nolint:gocritic
 
 		fieldType := field.Type
 
@@ -78,14 +112,16 @@ func (r *envDecoder) extractFieldMappings(t reflect.Type, yamlPath []string, fie
 			fieldType = fieldType.Elem()
 		}
 
-		if fieldType.Kind() == reflect.Struct {
+		if !isLeafType(fieldType) {
 			r.extractFieldMappings(fieldType, currentYAMLPath, currentFieldPath)
 		} else {
 			r.mappings = append(r.mappings, fieldMapping{
+				YAMLPath:  strings.Join(currentYAMLPath, "."),
 				EnvName:   r.yamlPathToEnvName(currentYAMLPath),
 				EnvAlias:  strings.ToUpper(r.cfg.EnvAliases[strings.Join(currentYAMLPath, ".")]),
 				FieldPath: currentFieldPath,
 				FieldType: field.Type,
+				Field:     field,
 			})
 		}
 	}
@@ -102,9 +138,11 @@ func (r *envDecoder) yamlPathToEnvName(yamlPath []string) string {
 }
 
 // getEnvValue retrieves the environment variable value for a given name.
-func (r *envDecoder) getEnvValue(envName string) (string, bool) {
+// It also returns the name of the variable that provided the value, which has the suffix `_FILE` if the value
+// was read from a file.
+func (r *envDecoder) getEnvValue(envName string) (string, string, bool) {
 	if value, ok := os.LookupEnv(envName); ok {
-		return value, true
+		return value, envName, true
 	}
 
 	if r.cfg.EnvFileLoading {
@@ -112,13 +150,13 @@ func (r *envDecoder) getEnvValue(envName string) (string, bool) {
 			content, err := os.ReadFile(filepath.Clean(filePath))
 			if err != nil {
 				r.cfg.Log.Warn(err)
-				return "", false
+				return "", "", false
 			}
-			return strings.TrimSpace(string(content)), true
+			return strings.TrimSpace(string(content)), envName + "_FILE", true
 		}
 	}
 
-	return "", false
+	return "", "", false
 }
 
 // setFieldValue sets a value on a nested field using reflection.
@@ -139,7 +177,8 @@ func (r *envDecoder) setFieldValue(rootValue reflect.Value, mapping fieldMapping
 		return ErrFieldNotSettable
 	}
 
-	return r.setConvertedValue(fieldValue, mapping.FieldType, value)
+	// Pointers along the path, including the field itself, have already been dereferenced.
+	return r.setConvertedValue(fieldValue, fieldValue.Type(), value)
 }
 
 // setConvertedValue converts the string value to the appropriate type and sets it.
@@ -152,26 +191,44 @@ func (r *envDecoder) setConvertedValue(fieldValue reflect.Value, fieldType refle
 		fieldType = fieldType.Elem()
 	}
 
+	// Types that know how to decode themselves take precedence over the kind-based conversion,
+	// e.g. logger.Level is an int but is configured as "debug".
+	if unmarshaler, ok := textUnmarshaler(fieldValue); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("cannot convert '%s' to %s: %w", value, fieldType, err)
+		}
+		return nil
+	}
+
+	if fieldType == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("cannot convert '%s' to duration: %w", value, err)
+		}
+		fieldValue.SetInt(int64(duration))
+		return nil
+	}
+
 	switch fieldType.Kind() {
 	case reflect.String:
 		fieldValue.SetString(value)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		intVal, err := strconv.ParseInt(value, 10, 64)
+		intVal, err := strconv.ParseInt(value, 10, fieldType.Bits())
 		if err != nil {
 			return fmt.Errorf("cannot convert '%s' to int: %w", value, err)
 		}
 		fieldValue.SetInt(intVal)
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		uintVal, err := strconv.ParseUint(value, 10, 64)
+		uintVal, err := strconv.ParseUint(value, 10, fieldType.Bits())
 		if err != nil {
 			return fmt.Errorf("cannot convert '%s' to uint: %w", value, err)
 		}
 		fieldValue.SetUint(uintVal)
 
 	case reflect.Float32, reflect.Float64:
-		floatVal, err := strconv.ParseFloat(value, 64)
+		floatVal, err := strconv.ParseFloat(value, fieldType.Bits())
 		if err != nil {
 			return fmt.Errorf("cannot convert '%s' to float: %w", value, err)
 		}
@@ -185,16 +242,10 @@ func (r *envDecoder) setConvertedValue(fieldValue reflect.Value, fieldType refle
 		fieldValue.SetBool(boolVal)
 
 	case reflect.Slice:
-		if fieldType.Elem().Kind() == reflect.String {
-			parts := strings.Split(value, ",")
-			slice := reflect.MakeSlice(fieldType, len(parts), len(parts))
-			for i, part := range parts {
-				slice.Index(i).SetString(strings.TrimSpace(part))
-			}
-			fieldValue.Set(slice)
-		} else {
-			return fmt.Errorf("unsupported slice type: %s", fieldType.Elem().Kind())
-		}
+		return r.setSliceValue(fieldValue, fieldType, value, EnvListSeparator)
+
+	case reflect.Map:
+		return r.setMapValue(fieldValue, fieldType, value)
 
 	default:
 		return fmt.Errorf("unsupported field type: %s", fieldType.Kind())
@@ -202,3 +253,80 @@ func (r *envDecoder) setConvertedValue(fieldValue reflect.Value, fieldType refle
 
 	return nil
 }
+
+// setSliceValue splits the value by the separator and converts every part into the slice's element type.
+// A byte slice is not split but receives the raw value.
+func (r *envDecoder) setSliceValue(fieldValue reflect.Value, fieldType reflect.Type, value, separator string) error {
+	if fieldType.Elem().Kind() == reflect.Uint8 {
+		fieldValue.SetBytes([]byte(value))
+		return nil
+	}
+
+	if strings.TrimSpace(value) == "" {
+		fieldValue.Set(reflect.MakeSlice(fieldType, 0, 0))
+		return nil
+	}
+
+	parts := strings.Split(value, separator)
+	slice := reflect.MakeSlice(fieldType, len(parts), len(parts))
+	for i, part := range parts {
+		if err := r.setConvertedValue(slice.Index(i), fieldType.Elem(), strings.TrimSpace(part)); err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(slice)
+
+	return nil
+}
+
+// setMapValue decodes a list of `key=value` pairs into a map, converting keys and values into the map's types.
+// Pairs are separated by EnvListSeparator, or by EnvMapSeparator if the map's values are slices themselves,
+// e.g. "admin=read_users,write_users;guest=read_users" for a map[string][]Entitlement.
+func (r *envDecoder) setMapValue(fieldValue reflect.Value, fieldType reflect.Type, value string) error {
+	separator := EnvListSeparator
+	if fieldType.Elem().Kind() == reflect.Slice && fieldType.Elem().Elem().Kind() != reflect.Uint8 {
+		separator = EnvMapSeparator
+	}
+
+	result := reflect.MakeMap(fieldType)
+	for _, pair := range strings.Split(value, separator) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("cannot convert '%s' to map entry: %w", pair, ErrInvalidMapEntry)
+		}
+
+		mapKey := reflect.New(fieldType.Key()).Elem()
+		if err := r.setConvertedValue(mapKey, fieldType.Key(), strings.TrimSpace(key)); err != nil {
+			return err
+		}
+
+		mapValue := reflect.New(fieldType.Elem()).Elem()
+		if err := r.setConvertedValue(mapValue, fieldType.Elem(), strings.TrimSpace(val)); err != nil {
+			return err
+		}
+
+		result.SetMapIndex(mapKey, mapValue)
+	}
+	fieldValue.Set(result)
+
+	return nil
+}
+
+// textUnmarshaler returns the encoding.TextUnmarshaler implemented by the address of the value, if any.
+func textUnmarshaler(value reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler, true
+		}
+	}
+	return nil, false
+}
+
+// isLeafType reports whether a type is decoded as a single value instead of being walked field by field.
+func isLeafType(t reflect.Type) bool {
+	return t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
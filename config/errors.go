@@ -5,7 +5,19 @@ import (
 )
 
 var (
-	ErrInvalidConfig      = errors.New("config must be a pointer to a struct")
-	ErrConfigFileNotFound = errors.New("config file not found in search paths")
-	ErrFieldNotSettable   = errors.New("field is not settable")
+	ErrInvalidConfig       = errors.New("config must be a pointer to a struct")
+	ErrConfigFileNotFound  = errors.New("config file not found in search paths")
+	ErrFieldNotSettable    = errors.New("field is not settable")
+	ErrInvalidMapEntry     = errors.New("map entry must be in the form key=value")
+	ErrInvalidDotEnvLine   = errors.New("dotenv line must be in the form KEY=value")
+	ErrEnvNotSet           = errors.New("environment variable is not set")
+	ErrEmptyCommand        = errors.New("command cannot be empty")
+	ErrInvalidSecretName   = errors.New("secret name must be a local path")
+	ErrSecretNotFound      = errors.New("secret not found")
+	ErrUnknownKey          = errors.New("unknown key")
+	ErrUnknownEnv          = errors.New("unknown environment variables")
+	ErrRequired            = errors.New("must be set")
+	ErrInvalidValidateRule = errors.New("invalid validation rule")
+	ErrInvalidFlags        = errors.New("invalid command-line flags")
+	ErrInvalidSchemaFormat = errors.New("schema format must be json or env")
 )
@@ -1 +1,39 @@
 package config
+
+import (
+	"reflect"
+)
+
+// fieldMapping represents a mapping between YAML path and struct field.
+type fieldMapping struct {
+	YAMLPath  string
+	EnvName   string
+	EnvAlias  string
+	FieldPath []int
+	FieldType reflect.Type
+	Field     reflect.StructField
+}
+
+// fieldByPath returns the field at the given path without allocating nil pointers along the way.
+// It returns an invalid value if the path crosses a nil pointer.
+func fieldByPath(value reflect.Value, fieldPath []int) reflect.Value {
+	for _, idx := range fieldPath {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}
+			}
+			value = value.Elem()
+		}
+		value = value.Field(idx)
+	}
+
+	return value
+}
+
+// fieldMappings returns the mappings of all leaf fields of the configuration.
+func fieldMappings(cfg *Config, config Configure) []fieldMapping {
+	decoder := envDecoder{cfg: cfg}
+	decoder.extractFieldMappings(reflect.TypeOf(config).Elem(), []string{}, []int{})
+
+	return decoder.mappings
+}
@@ -3,38 +3,148 @@ package config
 import (
 	"flag"
 	"fmt"
+	"io"
+	"reflect"
 )
 
+// fieldFlag is a flag.Value for a configuration field. It keeps the raw value until the configuration is loaded,
+// where it's decoded with the same conversion rules as environment variables.
+type fieldFlag struct {
+	decoder *envDecoder
+	mapping fieldMapping
+	value   string
+	isSet   bool
+}
+
+// IsBoolFlag allows boolean fields to be set without a value, e.g. `--http.tls`.
+func (r *fieldFlag) IsBoolFlag() bool {
+	return r.mapping.FieldType.Kind() == reflect.Bool
+}
+
+// Set validates the value by decoding it into a scratch value of the field's type and stores it.
+func (r *fieldFlag) Set(value string) error {
+	scratch := reflect.New(r.mapping.FieldType).Elem()
+	if err := r.decoder.setConvertedValue(scratch, r.mapping.FieldType, value); err != nil {
+		return err
+	}
+
+	r.value = value
+	r.isSet = true
+
+	return nil
+}
+
+// String returns the raw value of the flag.
+func (r *fieldFlag) String() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.value
+}
+
 // setupFlags sets up command-line flags and defines their usage messages based on the application's metadata.
-func setupFlags(cfg *Config) {
-	flag.StringVar(&cfg.ConfigFilePath, "config", "", "path to configuration file")
-	flag.Usage = func() {
-		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n\n", cfg.Metadata.AppName)
-		hasMetadata := false
-		if cfg.Metadata.Description != "" {
-			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Description: %s\n", cfg.Metadata.Description)
-			hasMetadata = true
+// Besides `-config`, a flag is registered for every leaf field of the configuration, named after its YAML path
+// (e.g. `--http.port`), using the `desc` tag as help text and the value set by SetDefaults as default.
+// It fails if the flag set already defines one of the built-in flags.
+func setupFlags(cfg *Config, config Configure) error {
+	flagSet := cfg.FlagSet
+
+	for _, name := range []string{"config", "print-config", "config-schema"} {
+		if flagSet.Lookup(name) != nil {
+			return fmt.Errorf("%w: flag -%s is already defined", ErrInvalidFlags, name)
 		}
-		if cfg.Metadata.Version != "" {
-			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Version: %s\n", cfg.Metadata.Version)
-			hasMetadata = true
+	}
+
+	flagSet.StringVar(&cfg.ConfigFilePath, "config", cfg.ConfigFilePath, "path to configuration file")
+	flagSet.BoolVar(&cfg.PrintConfig, "print-config", cfg.PrintConfig,
+		"print the effective configuration with the source of each value and exit")
+	flagSet.StringVar(&cfg.ConfigSchema, "config-schema", cfg.ConfigSchema,
+		"print the JSON Schema of the configuration file (json) or the environment variables (env) and exit")
+	cfg.fieldFlags = registerFieldFlags(cfg, flagSet, config)
+
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintf(flagSet.Output(), "Usage of %s:\n\n", cfg.Metadata.AppName)
+		writeMetadata(flagSet.Output(), cfg.Metadata)
+		flagSet.PrintDefaults()
+	}
+
+	if err := flagSet.Parse(cfg.Args); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFlags, err)
+	}
+
+	return nil
+}
+
+// registerFieldFlags registers a flag for every leaf field of the configuration and returns them.
+// Fields whose name collides with an already registered flag are skipped.
+func registerFieldFlags(cfg *Config, flagSet *flag.FlagSet, config Configure) []*fieldFlag {
+	decoder := &envDecoder{cfg: cfg}
+	rootValue := reflect.ValueOf(config).Elem()
+
+	SetDefaults(config)
+	decoder.extractFieldMappings(rootValue.Type(), []string{}, []int{})
+
+	fieldFlags := make([]*fieldFlag, 0, len(decoder.mappings))
+	for _, mapping := range decoder.mappings {
+		if flagSet.Lookup(mapping.YAMLPath) != nil {
+			continue
 		}
-		if cfg.Metadata.Author != "" {
-			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Author: %s\n", cfg.Metadata.Author)
-			hasMetadata = true
+
+		usage := mapping.Field.Tag.Get("desc")
+		if usage == "" {
+			usage = "sets " + mapping.YAMLPath
 		}
-		if cfg.Metadata.License != "" {
-			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "License: %s\n", cfg.Metadata.License)
-			hasMetadata = true
+		usage += " (env " + mapping.EnvName + ")"
+
+		value := &fieldFlag{
+			decoder: decoder,
+			mapping: mapping,
+			value:   formatValue(fieldByPath(rootValue, mapping.FieldPath)),
 		}
-		if cfg.Metadata.URL != "" {
-			_, _ = fmt.Fprintf(flag.CommandLine.Output(), "URL: %s\n", cfg.Metadata.URL)
-			hasMetadata = true
+		flagSet.Var(value, mapping.YAMLPath, usage)
+		fieldFlags = append(fieldFlags, value)
+	}
+
+	return fieldFlags
+}
+
+// applyFlags sets all fields of the configuration whose flags were given on the command line.
+func (r *Config) applyFlags(config Configure) error {
+	rootValue := reflect.ValueOf(config).Elem()
+
+	for _, fieldFlag := range r.fieldFlags {
+		if !fieldFlag.isSet {
+			continue
 		}
-		if hasMetadata {
-			_, _ = flag.CommandLine.Output().Write([]byte("\n"))
+
+		if err := fieldFlag.decoder.setFieldValue(rootValue, fieldFlag.mapping, fieldFlag.value); err != nil {
+			return fmt.Errorf("error setting --%s: %w", fieldFlag.mapping.YAMLPath, err)
 		}
-		flag.PrintDefaults()
+		r.Sources.set(fieldFlag.mapping.YAMLPath, Source{Kind: SourceFlag, Name: "--" + fieldFlag.mapping.YAMLPath})
+	}
+
+	return nil
+}
+
+// writeMetadata writes the description, version, author, license and URL of the application, followed by an
+// empty line if any of them is set.
+func writeMetadata(output io.Writer, metadata *Metadata) {
+	hasMetadata := false
+	for _, entry := range []struct{ label, value string }{
+		{"Description", metadata.Description},
+		{"Version", metadata.Version},
+		{"Author", metadata.Author},
+		{"License", metadata.License},
+		{"URL", metadata.URL},
+	} {
+		if entry.value != "" {
+			_, _ = fmt.Fprintf(output, "%s: %s\n", entry.label, entry.value)
+			hasMetadata = true
+		}
+	}
+
+	if hasMetadata {
+		_, _ = output.Write([]byte("\n"))
 	}
-	flag.Parse()
 }
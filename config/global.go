@@ -2,13 +2,59 @@ package config
 
 import (
 	"errors"
+	"os"
 )
 
+// OsExit is a variable for testing purposes. It's called after the configuration was printed with `--print-config`.
+//
+//nolint:gochecknoglobals // This is a mock for os.Exit used in tests to prevent actual program termination
+var OsExit = os.Exit
+
 // LoadConfig initializes and loads configuration into the provided Configure object using
-// a configuration file and environment variables.
+// a configuration file and environment variables. The file is decoded according to its extension.
 func LoadConfig(config Configure, opts ...Option) error {
+	cfg, err := newConfig(config, opts...)
+	if err != nil {
+		return err
+	}
+
+	return cfg.load(config)
+}
+
+// newConfig creates the Config for loading the provided Configure object by applying the options and the
+// providers it implements. Steps that may only run once per process, such as parsing flags, are performed here.
+func newConfig(config Configure, opts ...Option) (*Config, error) {
+	cfg, err := applyOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Flags {
+		if err = setupFlags(cfg, config); err != nil {
+			return nil, err
+		}
+
+		if cfg.ConfigSchema != "" {
+			if err = cfg.printSchema(config); err != nil {
+				return nil, err
+			}
+			OsExit(0)
+		}
+	}
+
+	if cfg.DotEnvFiles != nil {
+		if err = LoadDotEnv(cfg.DotEnvFiles...); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// applyOptions creates a Config with its defaults, the options and the providers implemented by the Configure object.
+func applyOptions(config Configure, opts ...Option) (*Config, error) {
 	if !IsStructPointer(config) {
-		return ErrInvalidConfig
+		return nil, ErrInvalidConfig
 	}
 
 	cfg := &Config{}
@@ -26,27 +72,63 @@ func LoadConfig(config Configure, opts ...Option) error {
 		cfg.EnvAliases = v.EnvAliases()
 	}
 
-	if cfg.Flags {
-		setupFlags(cfg)
+	return cfg, nil
+}
+
+// load populates the Configure object with its defaults, the configuration file(s), environment variables and
+// flags, then validates the result. Defaults and validation apply to all nested Configure values.
+// The origin of every field's value is recorded in Sources.
+func (r *Config) load(config Configure) error {
+	SetDefaults(config)
+
+	r.Sources = make(Sources)
+	for _, mapping := range fieldMappings(r, config) {
+		r.Sources.set(mapping.YAMLPath, Source{Kind: SourceDefault})
+	}
+
+	if r.LayeredFiles {
+		if err := r.loadLayeredConfigFiles(config); err != nil {
+			return err
+		}
+	} else {
+		configFile, err := r.loadConfigFile()
+		switch {
+		case err == nil:
+			r.ConfigFiles = []string{r.ConfigFilePath}
+			if err = r.decodeConfigFile(config, r.ConfigFilePath, configFile); err != nil {
+				return err
+			}
+		case !errors.Is(err, ErrConfigFileNotFound):
+			return err
+		}
 	}
 
-	config.SetDefaults()
+	if err := LoadFromEnv(r, config); err != nil {
+		return err
+	}
 
-	configFile, err := cfg.loadConfigFile()
-	if err != nil && !errors.Is(err, ErrConfigFileNotFound) {
+	if err := r.applyFlags(config); err != nil {
 		return err
 	}
-	if configFile != nil {
-		err = LoadFromYAML(cfg, config, configFile)
-		if err != nil {
+
+	if r.PrintConfig {
+		if err := r.printConfig(config); err != nil {
 			return err
 		}
+		OsExit(0)
 	}
 
-	err = LoadFromEnv(cfg, config)
+	return Validate(config)
+}
+
+// printConfig writes the effective configuration with the source of each value to the Output.
+func (r *Config) printConfig(config Configure) error {
+	data, err := MarshalEffectiveConfig(config, r.Sources)
 	if err != nil {
 		return err
 	}
 
-	return config.Validate()
+	_, err = r.Output.Write(data)
+
+	return err
 }
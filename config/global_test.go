@@ -1,25 +1,35 @@
 package config
 
 import (
+	"flag"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spacecafe/gobox/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 type mockConfig struct {
-	Host   string `yaml:"host"`
+	Host   string `desc:"host to listen on" yaml:"host"`
 	Port   int    `yaml:"port"`
 	Toggle bool   `yaml:"toggle"`
 	Name   struct {
 		First string `yaml:"first"`
 		Last  string `yaml:"last"`
 	} `yaml:"name"`
-	Tags []string `yaml:"tags"`
+	Tags    []string          `yaml:"tags"`
+	Ports   []int             `yaml:"ports"`
+	Labels  map[string]string `yaml:"labels"`
+	Roles   map[string][]role `yaml:"roles"`
+	Timeout time.Duration     `yaml:"timeout"`
+	Level   logger.Level      `yaml:"level"`
 }
 
+type role string
+
 func (r *mockConfig) SetDefaults() {
 	r.Host = "127.0.0.1"
 	r.Port = 8080
@@ -28,6 +38,7 @@ func (r *mockConfig) SetDefaults() {
 func (r *mockConfig) Validate() error { return nil }
 
 func TestLoadConfig(t *testing.T) {
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
 	tests := []struct {
 		name        string
 		config      Configure
@@ -224,6 +235,201 @@ toggle: true # inline comment
 				return configFile
 			},
 		},
+		{
+			name:    "json config file",
+			config:  &mockConfig{},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "json.example.com", tc.Host)
+				assert.Equal(t, 7000, tc.Port)
+				assert.Equal(t, "Jane", tc.Name.First)
+				assert.Equal(t, 10*time.Second, tc.Timeout)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				jsonContent := `{"host": "json.example.com", "port": 7000, "name": {"first": "Jane"}, "timeout": "10s"}`
+				configFile := filepath.Join(tmpDir, "config.json")
+				err := os.WriteFile(configFile, []byte(jsonContent), 0o600)
+				require.NoError(t, err)
+				return configFile
+			},
+		},
+		{
+			name:    "invalid json format",
+			config:  &mockConfig{},
+			wantErr: true,
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				configFile := filepath.Join(tmpDir, "config.json")
+				err := os.WriteFile(configFile, []byte(`{"host": "json.example.com",}`), 0o600)
+				require.NoError(t, err)
+				return configFile
+			},
+		},
+		{
+			name:    "toml config file",
+			config:  &mockConfig{},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "toml.example.com", tc.Host)
+				assert.Equal(t, 7000, tc.Port)
+				assert.True(t, tc.Toggle)
+				assert.Equal(t, "Doe", tc.Name.Last)
+				assert.Equal(t, []string{"foo", "bar"}, tc.Tags)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				tomlContent := `host = "toml.example.com"
+port = 7000
+toggle = true
+tags = ["foo", "bar"]
+
+[name]
+last = "Doe"
+`
+				configFile := filepath.Join(tmpDir, "config.toml")
+				err := os.WriteFile(configFile, []byte(tomlContent), 0o600)
+				require.NoError(t, err)
+				return configFile
+			},
+		},
+		{
+			name:    "dotenv file",
+			config:  &mockConfig{},
+			opts:    []Option{WithDotEnv()},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "dotenv.example.com", tc.Host)
+				assert.Equal(t, 6000, tc.Port)
+				assert.Equal(t, "Jane Doe", tc.Name.First)
+				assert.Equal(t, `O'Neil "Jr"`, tc.Name.Last)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				dotEnvContent := `# local overrides
+HOST=dotenv.example.com
+export PORT=7000 # ignored, already set
+NAME_FIRST='Jane Doe' # it's quoted
+NAME_LAST="O'Neil \"Jr\"" # "escaped" quotes
+`
+				dotEnvFile := filepath.Join(tmpDir, ".env")
+				err := os.WriteFile(dotEnvFile, []byte(dotEnvContent), 0o600)
+				require.NoError(t, err)
+				t.Chdir(tmpDir)
+				_ = os.Setenv("PORT", "6000")
+				t.Cleanup(func() {
+					_ = os.Unsetenv("HOST")
+					_ = os.Unsetenv("PORT")
+					_ = os.Unsetenv("NAME_FIRST")
+					_ = os.Unsetenv("NAME_LAST")
+				})
+				return ""
+			},
+		},
+		{
+			name:    "layered config files",
+			config:  &mockConfig{},
+			opts:    []Option{WithLayeredFiles(), WithEnvPrefix("APP")},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "production.example.com", tc.Host)
+				assert.Equal(t, 7000, tc.Port)
+				assert.True(t, tc.Toggle)
+				assert.Equal(t, "Jane", tc.Name.First)
+				assert.Equal(t, "Roe", tc.Name.Last)
+				assert.Equal(t, map[string]string{"team": "core", "env": "production", "tier": "backend"}, tc.Labels)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				files := map[string]string{
+					"config.yaml": `host: base.example.com
+port: 7000
+name:
+  first: Jane
+  last: Doe
+labels:
+  team: core
+  env: base
+`,
+					"config.production.yaml": `host: production.example.com
+labels:
+  env: production
+`,
+					"conf.d/10-toggle.yaml":   "toggle: true\nname:\n  last: Roe\n",
+					"conf.d/20-labels.json":   `{"labels": {"tier": "backend"}}`,
+					"conf.d/30-ignored.txt":   "host: ignored.example.com\n",
+					"config.staging.yaml":     "host: staging.example.com\n",
+					"conf.d/05-first.yaml":    "toggle: false\n",
+					"conf.d/15-disabled.yml~": "host: ignored.example.com\n",
+				}
+				require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "conf.d"), 0o700))
+				for name, content := range files {
+					err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600)
+					require.NoError(t, err)
+				}
+				_ = os.Setenv("APP_ENVIRONMENT", "production")
+				return filepath.Join(tmpDir, "config.yaml")
+			},
+			cleanupFunc: func(_ *testing.T) {
+				_ = os.Unsetenv("APP_ENVIRONMENT")
+			},
+		},
+		{
+			name:   "flags take precedence",
+			config: &mockConfig{},
+			opts: []Option{WithFlagSet(flagSet, []string{
+				"--port=6000", "--name.first", "Jim", "--toggle", "--timeout=1m",
+			})},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "env.example.com", tc.Host)
+				assert.Equal(t, 6000, tc.Port)
+				assert.Equal(t, "Jim", tc.Name.First)
+				assert.True(t, tc.Toggle)
+				assert.Equal(t, time.Minute, tc.Timeout)
+				assert.Equal(t, "8080", flagSet.Lookup("port").DefValue)
+				assert.Equal(t, "host to listen on (env HOST)", flagSet.Lookup("host").Usage)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				yamlContent := `host: yaml.example.com
+port: 7000
+`
+				configFile := filepath.Join(tmpDir, "config.yaml")
+				err := os.WriteFile(configFile, []byte(yamlContent), 0o600)
+				require.NoError(t, err)
+				_ = os.Setenv("HOST", "env.example.com")
+				_ = os.Setenv("PORT", "5555")
+				return configFile
+			},
+			cleanupFunc: func(_ *testing.T) {
+				_ = os.Unsetenv("HOST")
+				_ = os.Unsetenv("PORT")
+			},
+		},
+		{
+			name:   "invalid flag value",
+			config: &mockConfig{},
+			opts: []Option{WithFlagSet(
+				flag.NewFlagSet("test", flag.ContinueOnError), []string{"--port=invalid"},
+			)},
+			wantErr: true,
+		},
+		{
+			name:   "built-in flag already defined",
+			config: &mockConfig{},
+			opts: []Option{WithFlagSet(func() *flag.FlagSet {
+				flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+				flagSet.String("print-config", "", "")
+				return flagSet
+			}(), nil)},
+			wantErr: true,
+		},
 		{
 			name:    "environment variable",
 			config:  &mockConfig{},
@@ -271,6 +477,35 @@ port: 5555
 				return configFile
 			},
 		},
+		{
+			name:    "config file with tag resolvers",
+			config:  &mockConfig{},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, "tag.example.com", tc.Host)
+				assert.Equal(t, 5555, tc.Port)
+				assert.Equal(t, "Jane", tc.Name.First)
+			},
+			setupFunc: func(t *testing.T) string {
+				tmpDir := t.TempDir()
+				yamlContent := `host: !env TAG_HOST
+port: !env TAG_PORT
+name:
+  first: !base64 SmFuZQ==
+`
+				configFile := filepath.Join(tmpDir, "config.yaml")
+				err := os.WriteFile(configFile, []byte(yamlContent), 0o600)
+				require.NoError(t, err)
+				_ = os.Setenv("TAG_HOST", "tag.example.com")
+				_ = os.Setenv("TAG_PORT", "5555")
+				t.Cleanup(func() {
+					_ = os.Unsetenv("TAG_HOST")
+					_ = os.Unsetenv("TAG_PORT")
+				})
+				return configFile
+			},
+		},
 		{
 			name:    "config file with env expansion",
 			config:  &mockConfig{},
@@ -361,6 +596,46 @@ port: 5555
 				_ = os.Unsetenv("TAGS")
 			},
 		},
+		{
+			name:    "env typed values",
+			config:  &mockConfig{},
+			wantErr: false,
+			verify: func(t *testing.T, config Configure) {
+				tc := config.(*mockConfig)
+				assert.Equal(t, 30*time.Second, tc.Timeout)
+				assert.Equal(t, logger.DebugLevel, tc.Level)
+				assert.Equal(t, []int{80, 443}, tc.Ports)
+				assert.Equal(t, map[string]string{"team": "core", "env": "prod"}, tc.Labels)
+				assert.Equal(t, map[string][]role{"admin": {"read", "write"}, "guest": {"read"}}, tc.Roles)
+			},
+			setupFunc: func(_ *testing.T) string {
+				_ = os.Setenv("TIMEOUT", "30s")
+				_ = os.Setenv("LEVEL", "debug")
+				_ = os.Setenv("PORTS", "80, 443")
+				_ = os.Setenv("LABELS", "team=core,env=prod")
+				_ = os.Setenv("ROLES", "admin=read,write;guest=read")
+				return ""
+			},
+			cleanupFunc: func(_ *testing.T) {
+				_ = os.Unsetenv("TIMEOUT")
+				_ = os.Unsetenv("LEVEL")
+				_ = os.Unsetenv("PORTS")
+				_ = os.Unsetenv("LABELS")
+				_ = os.Unsetenv("ROLES")
+			},
+		},
+		{
+			name:    "env invalid typed value",
+			config:  &mockConfig{},
+			wantErr: true,
+			setupFunc: func(_ *testing.T) string {
+				_ = os.Setenv("LEVEL", "verbose")
+				return ""
+			},
+			cleanupFunc: func(_ *testing.T) {
+				_ = os.Unsetenv("LEVEL")
+			},
+		},
 		{
 			name:    "env aliases",
 			config:  &mockConfig{},
@@ -418,3 +693,27 @@ port: 5555
 		})
 	}
 }
+
+func TestParseDotEnvValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"unquoted", "value # comment", "value", false},
+		{"single quoted with comment", `'x' # it's`, "x", false},
+		{"double quoted with comment", `"a" # "b"`, "a", false},
+		{"escaped double quote", `"say \"hi\""`, `say "hi"`, false},
+		{"unterminated", `"value`, "", true},
+		{"text after quote", `'x'y`, "", true},
+		{"comment without space", `"x"#y`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotEnvValue(tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
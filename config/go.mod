@@ -3,6 +3,7 @@ module github.com/spacecafe/gobox/config
 go 1.24.0
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/spacecafe/gobox/logger v0.0.0-20251022124349-b4b23f362d45
 	github.com/stretchr/testify v1.11.1
 	gopkg.in/yaml.v3 v3.0.1
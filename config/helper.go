@@ -1,8 +1,13 @@
 package config
 
 import (
+	"encoding"
+	"fmt"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
+	"time"
 )
 
 // IsStructPointer checks if the provided input is a non-nil pointer to a struct and returns a boolean value.
@@ -22,3 +27,79 @@ func SysConfigDir() string {
 		return "/etc"
 	}
 }
+
+// formatValue returns the textual representation of a value in the same notation that is used to decode
+// environment variables, e.g. "30s" for durations or "a=1,b=2" for maps.
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	if text, ok := marshalText(value); ok {
+		return text
+	}
+
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes())
+		}
+		parts := make([]string, value.Len())
+		for i := range parts {
+			parts[i] = formatValue(value.Index(i))
+		}
+		return strings.Join(parts, EnvListSeparator)
+
+	case reflect.Map:
+		separator := EnvListSeparator
+		if value.Type().Elem().Kind() == reflect.Slice && value.Type().Elem().Elem().Kind() != reflect.Uint8 {
+			separator = EnvMapSeparator
+		}
+		parts := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			parts = append(parts, formatValue(key)+"="+formatValue(value.MapIndex(key)))
+		}
+		slices.Sort(parts)
+		return strings.Join(parts, separator)
+
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+// marshalText returns the text of a value implementing encoding.TextMarshaler on its value or pointer receiver.
+func marshalText(value reflect.Value) (string, bool) {
+	if !value.CanInterface() {
+		return "", false
+	}
+
+	// Copy non-addressable values, e.g. map entries, to call methods with pointer receivers.
+	if !value.CanAddr() {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer.Elem()
+	}
+
+	marshaler, ok := value.Addr().Interface().(encoding.TextMarshaler)
+	if !ok {
+		return "", false
+	}
+
+	text, err := marshaler.MarshalText()
+	if err != nil {
+		return "", false
+	}
+
+	return string(text), true
+}
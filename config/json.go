@@ -0,0 +1,28 @@
+package config
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFromJSON loads configuration data from a JSON byte slice into a Configure object using a Config.
+// JSON is a subset of YAML, so the content is decoded with the same yaml struct tags as YAML files.
+func LoadFromJSON(cfg *Config, config Configure, data []byte) (err error) {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// Parse the content as JSON first to report syntax errors in terms of JSON rather than YAML.
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(data, &node); err != nil {
+		return
+	}
+
+	return loadFromYAMLNode(cfg, config, &node)
+}
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"flag"
+	"strings"
+	"time"
+
 	"github.com/spacecafe/gobox/logger"
 )
 
@@ -29,6 +33,24 @@ func WithEnvPrefix(prefix string) Option {
 	}
 }
 
+// WithFlagSet enables flag-based configuration using the given flag set and arguments instead of
+// flag.CommandLine and os.Args, e.g. for subcommands or tests.
+func WithFlagSet(flagSet *flag.FlagSet, args []string) Option {
+	return func(c *Config) {
+		c.Flags = true
+		c.FlagSet = flagSet
+		c.Args = args
+	}
+}
+
+// WithLayeredFiles enables the layered mode, which merges the system, user, and local configuration files,
+// their `conf.d` fragments, and the overlay of the current environment instead of loading a single file.
+func WithLayeredFiles() Option {
+	return func(c *Config) {
+		c.LayeredFiles = true
+	}
+}
+
 // WithMetadata sets the metadata field in Config to the provided *types.Metadata value.
 func WithMetadata(metadata *Metadata) Option {
 	return func(c *Config) {
@@ -43,7 +65,36 @@ func WithEnvAliases(envAliases map[string]string) Option {
 	}
 }
 
+// WithDecoder registers a FileDecoder for configuration files with the given extension, e.g. ".ini".
+// It replaces the built-in decoder if the extension is already registered.
+func WithDecoder(extension string, decoder FileDecoder) Option {
+	return func(c *Config) {
+		c.Decoders[strings.ToLower(extension)] = decoder
+	}
+}
+
+// WithDotEnv enables loading the given dotenv files into the process environment before environment variables
+// are decoded. If no paths are given, DefaultDotEnvFile is loaded from the working directory.
+func WithDotEnv(paths ...string) Option {
+	return func(c *Config) {
+		if len(paths) == 0 {
+			paths = []string{DefaultDotEnvFile}
+		}
+		c.DotEnvFiles = paths
+	}
+}
+
+// WithEnvironmentVariable sets the environment variable that selects the environment overlay in layered mode.
+// The name is used as is, without the EnvPrefix.
+func WithEnvironmentVariable(name string) Option {
+	return func(c *Config) {
+		c.EnvironmentVariable = name
+	}
+}
+
 // WithFlags is an option that enables flag-based configuration.
+// Besides `-config`, a flag is created for every field of the configuration, e.g. `--http.port`.
+// Flags take precedence over environment variables, which take precedence over the configuration file.
 func WithFlags() Option {
 	return func(c *Config) {
 		c.Flags = true
@@ -64,9 +115,33 @@ func WithYAMLEnvExpansion() Option {
 	}
 }
 
+// WithStrict enables the strict mode, which rejects keys of configuration files that don't match a field,
+// reporting their line and column, and environment variables with the EnvPrefix that match no field or alias.
+func WithStrict() Option {
+	return func(c *Config) {
+		c.Strict = true
+	}
+}
+
+// WithTagResolver registers a TagResolver for scalar values with the given YAML tag, e.g. "!secret".
+// It replaces the resolver if the tag is already registered.
+func WithTagResolver(tag string, resolver TagResolver) Option {
+	return func(c *Config) {
+		c.TagResolvers["!"+strings.TrimPrefix(tag, "!")] = resolver
+	}
+}
+
 // WithYAMLFileLoading enables YAML file loading syntax `!file` to replace the value with the file content.
 func WithYAMLFileLoading() Option {
 	return func(c *Config) {
 		c.YAMLFileLoading = true
+		c.TagResolvers["!file"] = FileResolver
+	}
+}
+
+// WithWatchInterval sets the interval in which a Watcher checks the configuration files for changes.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(c *Config) {
+		c.WatchInterval = interval
 	}
 }
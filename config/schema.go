@@ -0,0 +1,364 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SchemaFormatJSON selects the JSON Schema of the configuration file for `--config-schema`.
+	SchemaFormatJSON = "json"
+
+	// SchemaFormatEnv selects the reference of the environment variables for `--config-schema`.
+	SchemaFormatEnv = "env"
+
+	// JSONSchemaDialect is the version of the generated JSON Schema.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// JSONSchema describes the structure of a configuration file in the JSON Schema format.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+}
+
+// EnvVar describes an environment variable that sets a field of the configuration.
+type EnvVar struct {
+	// Name is the name of the variable including the EnvPrefix, e.g. "APP_HTTP_PORT".
+	Name string
+
+	// Alias is the alternative name of the variable, or empty if there is none.
+	Alias string
+
+	// FileLoading reports whether the variable can be read from a file referenced by the `_FILE` variant.
+	FileLoading bool
+
+	// YAMLPath is the path of the field in the configuration file, e.g. "http.port".
+	YAMLPath string
+
+	// Type is the Go type of the field.
+	Type string
+
+	// Default is the value set by SetDefaults in the notation of environment variables.
+	Default string
+
+	// Description is the value of the field's `desc` tag.
+	Description string
+}
+
+// GenerateJSONSchema returns the JSON Schema of the configuration file for the type of the Configure object.
+// Defaults are taken from SetDefaults, descriptions from `desc` tags and constraints from `validate` tags.
+// The Configure object itself is not modified.
+func GenerateJSONSchema(config Configure, opts ...Option) ([]byte, error) {
+	cfg, err := applyOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.jsonSchema(config)
+}
+
+// EnvVars returns the environment variables that set the fields of the Configure object.
+// Names, aliases and the `_FILE` variants depend on the options, e.g. WithEnvPrefix or WithEnvFileLoading.
+func EnvVars(config Configure, opts ...Option) ([]EnvVar, error) {
+	cfg, err := applyOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.envVars(config), nil
+}
+
+// GenerateEnvReference returns a Markdown table of the environment variables that set the fields of the
+// Configure object, including aliases and `_FILE` variants.
+func GenerateEnvReference(config Configure, opts ...Option) ([]byte, error) {
+	cfg, err := applyOptions(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.envReference(config), nil
+}
+
+// jsonSchema returns the indented JSON Schema of the configuration file.
+func (r *Config) jsonSchema(config Configure) ([]byte, error) {
+	defaults := newDefaults(config)
+	schema := typeSchema(defaults.Type(), defaults, "")
+	schema.Schema = JSONSchemaDialect
+	schema.Title = r.Metadata.AppName
+	schema.Description = r.Metadata.Description
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// envVars returns the environment variables that set the fields of the configuration.
+func (r *Config) envVars(config Configure) []EnvVar {
+	defaults := newDefaults(config)
+	mappings := fieldMappings(r, config)
+
+	envVars := make([]EnvVar, 0, len(mappings))
+	for _, mapping := range mappings {
+		value := fieldByPath(defaults, mapping.FieldPath)
+
+		defaultValue := formatValue(value)
+		if redacted, ok := redact(mapping, value); ok {
+			defaultValue = redacted
+		}
+
+		envVars = append(envVars, EnvVar{
+			Name:        mapping.EnvName,
+			Alias:       mapping.EnvAlias,
+			FileLoading: r.EnvFileLoading,
+			YAMLPath:    mapping.YAMLPath,
+			Type:        mapping.FieldType.String(),
+			Default:     defaultValue,
+			Description: mapping.Field.Tag.Get("desc"),
+		})
+	}
+
+	return envVars
+}
+
+// envReference returns a Markdown table of the environment variables that set the fields of the configuration.
+func (r *Config) envReference(config Configure) []byte {
+	var builder strings.Builder
+	builder.WriteString("| Variable | Alias | Type | Default | Description |\n")
+	builder.WriteString("|----------|-------|------|---------|-------------|\n")
+
+	for _, envVar := range r.envVars(config) {
+		_, _ = fmt.Fprintf(&builder, "| %s | %s | %s | %s | %s |\n",
+			envVarNames(envVar.Name, envVar.FileLoading),
+			envVarNames(envVar.Alias, envVar.FileLoading),
+			markdownCell(envVar.Type, true),
+			markdownCell(envVar.Default, true),
+			markdownCell(envVar.Description, false),
+		)
+	}
+
+	return []byte(builder.String())
+}
+
+// printSchema writes the schema selected by `--config-schema` to the Output.
+func (r *Config) printSchema(config Configure) error {
+	var data []byte
+
+	switch r.ConfigSchema {
+	case SchemaFormatJSON:
+		schema, err := r.jsonSchema(config)
+		if err != nil {
+			return err
+		}
+		data = append(schema, '\n')
+	case SchemaFormatEnv:
+		data = r.envReference(config)
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSchemaFormat, r.ConfigSchema)
+	}
+
+	_, err := r.Output.Write(data)
+
+	return err
+}
+
+// newDefaults returns a new value of the Configure object's type initialized by SetDefaults.
+func newDefaults(config Configure) reflect.Value {
+	defaults := reflect.New(reflect.TypeOf(config).Elem())
+	SetDefaults(defaults.Interface().(Configure)) //nolint:forcetypeassert // The type is the type of a Configure.
+
+	return defaults.Elem()
+}
+
+// typeSchema returns the schema of a type. The value, if valid, provides the default.
+func typeSchema(t reflect.Type, value reflect.Value, rules string) *JSONSchema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if value.IsValid() && value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+	}
+
+	schema := &JSONSchema{}
+
+	switch {
+	case t == durationType || isTextType(t):
+		schema.Type = "string"
+		setSchemaDefault(schema, value, formatValue)
+	case t.Kind() == reflect.Struct:
+		schema.Type = "object"
+		schema.Properties = make(map[string]*JSONSchema)
+		addStructProperties(schema, t, value)
+	case t.Kind() == reflect.Map:
+		schema.Type = "object"
+		schema.AdditionalProperties = typeSchema(t.Elem(), reflect.Value{}, "")
+		setSchemaDefault(schema, value, nil)
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8:
+		schema.Type = "string"
+		setSchemaDefault(schema, value, formatValue)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		schema.Type = "array"
+		schema.Items = typeSchema(t.Elem(), reflect.Value{}, "")
+		setSchemaDefault(schema, value, nil)
+	default:
+		schema.Type = kindSchemaType(t.Kind())
+		setSchemaDefault(schema, value, nil)
+	}
+
+	addSchemaConstraints(schema, rules)
+
+	return schema
+}
+
+// addStructProperties adds the fields of a struct as properties to the schema. Inlined fields are flattened.
+func addStructProperties(schema *JSONSchema, t reflect.Type, value reflect.Value) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !isConfigField(field) {
+			continue
+		}
+
+		var fieldValue reflect.Value
+		if value.IsValid() {
+			fieldValue = value.Field(i)
+		}
+
+		name := yamlName(field)
+		if name == "" {
+			inlined := typeSchema(field.Type, fieldValue, "")
+			for key, property := range inlined.Properties {
+				schema.Properties[key] = property
+			}
+			schema.Required = append(schema.Required, inlined.Required...)
+			continue
+		}
+
+		rules := field.Tag.Get("validate")
+		property := typeSchema(field.Type, fieldValue, rules)
+		property.Description = field.Tag.Get("desc")
+		schema.Properties[name] = property
+
+		if fieldValue.IsValid() && fieldValue.Kind() == reflect.Ptr {
+			fieldValue = fieldValue.Elem()
+		}
+		if property.Default != nil && fieldValue.IsValid() {
+			if redacted, ok := redact(fieldMapping{Field: field}, fieldValue); ok {
+				property.Default = redacted
+			}
+		}
+
+		if hasRule(rules, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// addSchemaConstraints translates the `min` and `max` rules of a `validate` tag to schema constraints.
+func addSchemaConstraints(schema *JSONSchema, rules string) {
+	for rule := range strings.SplitSeq(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name != "min" && name != "max" {
+			continue
+		}
+
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			continue
+		}
+		length := int(bound)
+
+		switch {
+		case schema.Type == "string" && name == "min":
+			schema.MinLength = &length
+		case schema.Type == "string":
+			schema.MaxLength = &length
+		case schema.Type == "array" && name == "min":
+			schema.MinItems = &length
+		case schema.Type == "array":
+			schema.MaxItems = &length
+		case name == "min":
+			schema.Minimum = &bound
+		default:
+			schema.Maximum = &bound
+		}
+	}
+}
+
+// setSchemaDefault sets the default of the schema to the value unless it's invalid or zero.
+// The value is converted by the format function if one is given.
+func setSchemaDefault(schema *JSONSchema, value reflect.Value, format func(reflect.Value) string) {
+	if !value.IsValid() || value.IsZero() {
+		return
+	}
+
+	if format != nil {
+		schema.Default = format(value)
+		return
+	}
+
+	schema.Default = value.Interface()
+}
+
+// kindSchemaType returns the JSON Schema type of a basic kind.
+func kindSchemaType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	default:
+		return ""
+	}
+}
+
+// isTextType reports whether values of the type are represented as text, i.e. the type implements
+// encoding.TextUnmarshaler.
+func isTextType(t reflect.Type) bool {
+	return t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// envVarNames returns the name of an environment variable and its `_FILE` variant formatted as Markdown code.
+func envVarNames(name string, fileLoading bool) string {
+	if name == "" {
+		return ""
+	}
+
+	if fileLoading {
+		return "`" + name + "`, `" + name + "_FILE`"
+	}
+
+	return "`" + name + "`"
+}
+
+// markdownCell escapes the text for a cell of a Markdown table, optionally formatted as code.
+func markdownCell(text string, code bool) string {
+	if text == "" {
+		return ""
+	}
+
+	text = strings.ReplaceAll(text, "|", "\\|")
+	if code {
+		return "`" + text + "`"
+	}
+
+	return text
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaConfig struct {
+	NestedServer `yaml:",inline"`
+
+	Password string            `secret:"true" yaml:"password"`
+	Labels   map[string]string `yaml:"labels"`
+	Servers  []NestedServer    `validate:"max=3" yaml:"servers"`
+	Admin    *NestedServer     `yaml:"admin"`
+}
+
+func (r *schemaConfig) SetDefaults() {
+	r.Password = "changeme"
+}
+
+func (r *schemaConfig) Validate() error { return nil }
+
+func (r *schemaConfig) EnvAliases() map[string]string {
+	return map[string]string{"port": "listen_port"}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	data, err := GenerateJSONSchema(&mockConfig{}, WithMetadata(&Metadata{AppName: "app", Description: "test app"}))
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, JSONSchemaDialect, schema["$schema"])
+	assert.Equal(t, "app", schema["title"])
+	assert.Equal(t, "test app", schema["description"])
+	assert.Equal(t, "object", schema["type"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "default": "127.0.0.1", "description": "host to listen on"},
+		properties["host"])
+	assert.Equal(t, map[string]any{"type": "integer", "default": float64(8080)}, properties["port"])
+	assert.Equal(t, map[string]any{"type": "boolean"}, properties["toggle"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["timeout"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["level"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, properties["tags"])
+	assert.Equal(t, map[string]any{
+		"type":                 "object",
+		"additionalProperties": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	}, properties["roles"])
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"first": map[string]any{"type": "string"},
+			"last":  map[string]any{"type": "string"},
+		},
+	}, properties["name"])
+}
+
+func TestGenerateJSONSchema_Nested(t *testing.T) {
+	data, err := GenerateJSONSchema(&schemaConfig{})
+	require.NoError(t, err)
+
+	var schema JSONSchema
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, []string{"host"}, schema.Required)
+	assert.Equal(t, "localhost", schema.Properties["host"].Default)
+	assert.InDelta(t, 1, *schema.Properties["port"].Minimum, 0)
+	assert.InDelta(t, 65535, *schema.Properties["port"].Maximum, 0)
+	assert.Equal(t, "1s", schema.Properties["timeout"].Default)
+	assert.Equal(t, RedactedValue, schema.Properties["password"].Default)
+	assert.Equal(t, 3, *schema.Properties["servers"].MaxItems)
+	assert.Equal(t, "object", schema.Properties["servers"].Items.Type)
+	assert.Equal(t, []string{"host"}, schema.Properties["admin"].Required)
+	assert.Nil(t, schema.Properties["admin"].Properties["host"].Default)
+
+	_, err = GenerateJSONSchema(nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestEnvVars(t *testing.T) {
+	envVars, err := EnvVars(&schemaConfig{}, WithEnvPrefix("APP"), WithEnvFileLoading())
+	require.NoError(t, err)
+
+	require.Len(t, envVars, 11)
+	assert.Equal(t, EnvVar{
+		Name:        "APP_PORT",
+		Alias:       "LISTEN_PORT",
+		FileLoading: true,
+		YAMLPath:    "port",
+		Type:        "int",
+		Default:     "8080",
+	}, envVars[1])
+	assert.Equal(t, RedactedValue, envVars[4].Default)
+}
+
+func TestGenerateEnvReference(t *testing.T) {
+	data, err := GenerateEnvReference(&mockConfig{}, WithEnvPrefix("APP"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), "| Variable | Alias | Type | Default | Description |\n")
+	assert.Contains(t, string(data), "| `APP_HOST` |  | `string` | `127.0.0.1` | host to listen on |\n")
+	assert.Contains(t, string(data), "| `APP_ROLES` |  | `map[string][]config.role` |  |  |\n")
+
+	data, err = GenerateEnvReference(&schemaConfig{}, WithEnvFileLoading())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| `PORT`, `PORT_FILE` | `LISTEN_PORT`, `LISTEN_PORT_FILE` | `int` | `8080` |  |\n")
+}
+
+func TestLoadConfig_ConfigSchema(t *testing.T) {
+	exitCode := -1
+	OsExit = func(code int) {
+		exitCode = code
+	}
+	t.Cleanup(func() {
+		OsExit = os.Exit
+	})
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr error
+	}{
+		{name: "json", format: SchemaFormatJSON, want: `"$schema": "` + JSONSchemaDialect + `"`},
+		{name: "env", format: SchemaFormatEnv, want: "| `HOST` |"},
+		{name: "invalid", format: "xml", wantErr: ErrInvalidSchemaFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode = -1
+			output := &bytes.Buffer{}
+			err := LoadConfig(&mockConfig{},
+				WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--config-schema=" + tt.format}),
+				func(cfg *Config) {
+					cfg.Output = output
+				},
+			)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, -1, exitCode)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 0, exitCode)
+			assert.Contains(t, output.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SecretBase64Prefix marks the text of a Secret as standard base64-encoded (RFC 4648), e.g. `base64:c2VjcmV0`.
+// Text without the prefix is used as is.
+const SecretBase64Prefix = "base64:"
+
+// Secret holds sensitive data, such as passwords or cryptographic keys. It redacts itself when it's printed,
+// logged or marshaled, so the data is only accessible through Reveal.
+//
+// It's decoded from YAML, JSON, environment variables, `_FILE` variables and flags as raw text or, with the
+// SecretBase64Prefix, as base64-encoded binary data.
+type Secret struct {
+	value []byte
+}
+
+// NewSecret creates a Secret holding a copy of the given data.
+func NewSecret(value []byte) Secret {
+	return Secret{value: append([]byte(nil), value...)}
+}
+
+// Reveal returns the sensitive data. The returned slice must not be modified.
+func (r Secret) Reveal() []byte {
+	return r.value
+}
+
+// Len returns the length of the sensitive data in bytes.
+func (r Secret) Len() int {
+	return len(r.value)
+}
+
+// IsEmpty reports whether the Secret holds no data.
+func (r Secret) IsEmpty() bool {
+	return len(r.value) == 0
+}
+
+// Equal compares the sensitive data with the given data in constant time.
+func (r Secret) Equal(value []byte) bool {
+	return subtle.ConstantTimeCompare(r.value, value) == 1
+}
+
+// Redact returns RedactedValue, or an empty string if the Secret holds no data.
+func (r Secret) Redact() string {
+	if r.IsEmpty() {
+		return ""
+	}
+
+	return RedactedValue
+}
+
+// String returns the redacted representation of the Secret.
+func (r Secret) String() string {
+	return r.Redact()
+}
+
+// GoString returns the redacted representation of the Secret for the `%#v` verb.
+func (r Secret) GoString() string {
+	return "config.Secret(" + r.Redact() + ")"
+}
+
+// Format writes the redacted representation of the Secret for all verbs, including `%x` and `%+v`.
+func (r Secret) Format(state fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && state.Flag('#'):
+		_, _ = state.Write([]byte(r.GoString()))
+	case verb == 'q':
+		_, _ = fmt.Fprintf(state, "%q", r.Redact())
+	default:
+		_, _ = state.Write([]byte(r.Redact()))
+	}
+}
+
+// MarshalText returns the redacted representation of the Secret.
+func (r Secret) MarshalText() ([]byte, error) {
+	return []byte(r.Redact()), nil
+}
+
+// MarshalJSON returns the redacted representation of the Secret as JSON string.
+func (r Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Redact())
+}
+
+// UnmarshalText decodes the text into the Secret. Text with the SecretBase64Prefix is base64-decoded,
+// any other text is used as is.
+func (r *Secret) UnmarshalText(text []byte) error {
+	encoded, ok := strings.CutPrefix(string(text), SecretBase64Prefix)
+	if !ok {
+		r.value = append([]byte(nil), text...)
+		return nil
+	}
+
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to decode secret: %w", err)
+	}
+	r.value = value
+
+	return nil
+}
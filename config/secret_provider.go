@@ -0,0 +1,124 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSecretKey is the key read from a secret of an HTTPSecretProvider if the name does not contain one.
+	DefaultSecretKey = "value"
+
+	// DefaultSecretMount is the mount path of the key/value secrets engine used by an HTTPSecretProvider.
+	DefaultSecretMount = "secret"
+
+	// DefaultSecretTimeout is the maximum duration of a request made by an HTTPSecretProvider.
+	DefaultSecretTimeout = 10 * time.Second
+)
+
+// SecretProvider looks up secrets by name for the `!secret` tag.
+type SecretProvider interface {
+	// Secret returns the value of the secret with the given name.
+	Secret(name string) (string, error)
+}
+
+// FileSecretProvider reads secrets from files within a directory, e.g. Docker or Kubernetes secrets in /run/secrets.
+type FileSecretProvider struct {
+	// Dir is the directory containing one file per secret.
+	Dir string
+}
+
+// NewFileSecretProvider creates a FileSecretProvider for the given directory.
+func NewFileSecretProvider(dir string) *FileSecretProvider {
+	return &FileSecretProvider{Dir: dir}
+}
+
+// Secret returns the trimmed content of the file with the given name. The name must not leave the directory.
+func (r *FileSecretProvider) Secret(name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", ErrInvalidSecretName
+	}
+
+	content, err := os.ReadFile(filepath.Join(r.Dir, name))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// HTTPSecretProvider reads secrets from the key/value secrets engine (version 2) of a Vault-compatible HTTP API.
+// Secret names have the form `path#key`, e.g. `app/database#password`. Without a key, DefaultSecretKey is read.
+type HTTPSecretProvider struct {
+	// Client is the HTTP client used for requests.
+	Client *http.Client
+
+	// Address is the base URL of the API, e.g. https://vault.example.com:8200.
+	Address string
+
+	// Token is sent as X-Vault-Token header to authenticate requests.
+	Token string
+
+	// Mount is the mount path of the key/value secrets engine.
+	Mount string
+}
+
+// NewHTTPSecretProvider creates an HTTPSecretProvider for the given address and token with default settings.
+func NewHTTPSecretProvider(address, token string) *HTTPSecretProvider {
+	return &HTTPSecretProvider{
+		Client:  &http.Client{Timeout: DefaultSecretTimeout},
+		Address: strings.TrimSuffix(address, "/"),
+		Token:   token,
+		Mount:   DefaultSecretMount,
+	}
+}
+
+// Secret requests the secret at the path of the given name and returns the value of its key.
+func (r *HTTPSecretProvider) Secret(name string) (string, error) {
+	path, key, ok := strings.Cut(name, "#")
+	if !ok {
+		key = DefaultSecretKey
+	}
+
+	url := r.Address + "/v1/" + r.Mount + "/data/" + strings.TrimPrefix(path, "/")
+
+	//nolint:noctx // The client defines the timeout of the request.
+	request, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("X-Vault-Token", r.Token)
+
+	response, err := r.Client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, response.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	value, ok := body.Data.Data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: key %s", ErrSecretNotFound, key)
+	}
+
+	return fmt.Sprint(value), nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type secretConfig struct {
+	Password Secret            `yaml:"password"`
+	Key      Secret            `yaml:"key"`
+	Tokens   []Secret          `yaml:"tokens"`
+	Users    map[string]Secret `yaml:"users"`
+}
+
+func (r *secretConfig) SetDefaults() {}
+
+func (r *secretConfig) Validate() error { return nil }
+
+func TestSecret_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "raw", text: "secret", want: []byte("secret")},
+		{name: "base64", text: "base64:c2VjcmV0", want: []byte("secret")},
+		{name: "raw looking like base64", text: "c2VjcmV0", want: []byte("c2VjcmV0")},
+		{name: "empty", text: ""},
+		{name: "invalid base64", text: "base64:%%%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var secret Secret
+			err := secret.UnmarshalText([]byte(tt.text))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, secret.Reveal())
+			assert.Equal(t, len(tt.want), secret.Len())
+			assert.True(t, secret.Equal(tt.want))
+		})
+	}
+}
+
+func TestSecret_Redaction(t *testing.T) {
+	secret := NewSecret([]byte("secret"))
+	wrapper := struct {
+		Secret  Secret
+		Pointer *Secret
+	}{Secret: secret, Pointer: &secret}
+
+	for _, format := range []string{"%s", "%v", "%+v", "%#v", "%x", "%q", "%d"} {
+		assert.NotContains(t, fmt.Sprintf(format, secret), "secret", format)
+		assert.NotContains(t, fmt.Sprintf(format, wrapper), "secret", format)
+	}
+	assert.Equal(t, RedactedValue, secret.String())
+	assert.Equal(t, `"******"`, fmt.Sprintf("%q", secret))
+	assert.Equal(t, "config.Secret(******)", fmt.Sprintf("%#v", secret))
+
+	text, err := secret.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, RedactedValue, string(text))
+
+	data, err := json.Marshal(wrapper)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Secret":"******","Pointer":"******"}`, string(data))
+
+	data, err = yaml.Marshal(wrapper)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret\n")
+
+	assert.Empty(t, Secret{}.String())
+	assert.True(t, Secret{}.IsEmpty())
+}
+
+func TestLoadConfig_Secret(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(configFile, []byte(
+		"password: yaml-secret\ntokens: [a, 'base64:Yg==']\nusers:\n  admin: !base64 cGFzcw==\n"), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, []byte("file-secret\n"), 0o600))
+	t.Setenv("SECRET_KEY_FILE", keyFile)
+
+	config := &secretConfig{}
+	require.NoError(t, LoadConfig(config, WithConfigFilePath(configFile), WithEnvPrefix("SECRET"), WithEnvFileLoading()))
+
+	assert.Equal(t, []byte("yaml-secret"), config.Password.Reveal())
+	assert.Equal(t, []byte("file-secret"), config.Key.Reveal())
+	assert.Equal(t, []Secret{NewSecret([]byte("a")), NewSecret([]byte("b"))}, config.Tokens)
+	assert.Equal(t, []byte("pass"), config.Users["admin"].Reveal())
+
+	t.Setenv("SECRET_TOKENS", "c,d")
+	require.NoError(t, LoadConfig(config, WithConfigFilePath(configFile), WithEnvPrefix("SECRET")))
+	assert.Equal(t, []Secret{NewSecret([]byte("c")), NewSecret([]byte("d"))}, config.Tokens)
+	assert.NotContains(t, fmt.Sprintf("%+v", config), "yaml-secret")
+}
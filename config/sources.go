@@ -0,0 +1,203 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue replaces the values of secret fields when the configuration is printed.
+const RedactedValue = "******"
+
+// SourceKind describes where the value of a configuration field came from.
+type SourceKind string
+
+const (
+	// SourceDefault marks values set by SetDefaults.
+	SourceDefault SourceKind = "default"
+
+	// SourceFile marks values read from a configuration file.
+	SourceFile SourceKind = "file"
+
+	// SourceEnv marks values read from the environment variable of a field.
+	SourceEnv SourceKind = "env"
+
+	// SourceEnvAlias marks values read from an alias of the environment variable of a field.
+	SourceEnvAlias SourceKind = "alias"
+
+	// SourceEnvFile marks values read from the file referenced by a `_FILE` environment variable.
+	SourceEnvFile SourceKind = "env-file"
+
+	// SourceFlag marks values read from a command-line flag.
+	SourceFlag SourceKind = "flag"
+)
+
+// Redactor is implemented by types whose values must be masked when the configuration is printed, e.g. secrets.
+type Redactor interface {
+	// Redact returns the masked representation of the value.
+	Redact() string
+}
+
+// Source describes the origin of the value of a configuration field.
+type Source struct {
+	// Kind is the type of the origin.
+	Kind SourceKind
+
+	// Name identifies the origin, e.g. the path of a file or the name of an environment variable.
+	Name string
+}
+
+// String returns the kind and name of the Source, e.g. "env APP_HTTP_PORT".
+func (r Source) String() string {
+	if r.Name == "" {
+		return string(r.Kind)
+	}
+
+	return string(r.Kind) + " " + r.Name
+}
+
+// Sources maps the YAML paths of the configuration's fields, e.g. "http.port", to the origin of their values.
+type Sources map[string]Source
+
+// LoadConfigWithSources works like LoadConfig and additionally returns the origin of every field's value.
+func LoadConfigWithSources(config Configure, opts ...Option) (Sources, error) {
+	cfg, err := newConfig(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.load(config)
+
+	return cfg.Sources, err
+}
+
+// MarshalEffectiveConfig returns the configuration as YAML document with the origin of each value as a comment.
+// Values of fields tagged with `secret:"true"` or implementing Redactor are masked.
+func MarshalEffectiveConfig(config Configure, sources Sources) ([]byte, error) {
+	if !IsStructPointer(config) {
+		return nil, ErrInvalidConfig
+	}
+
+	rootValue := reflect.ValueOf(config).Elem()
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, mapping := range fieldMappings(&Config{}, config) {
+		keys := strings.Split(mapping.YAMLPath, ".")
+
+		parent := root
+		for _, key := range keys[:len(keys)-1] {
+			parent = childMappingNode(parent, key)
+		}
+
+		valueNode, err := effectiveValueNode(mapping, fieldByPath(rootValue, mapping.FieldPath))
+		if err != nil {
+			return nil, err
+		}
+		if source, ok := sources[mapping.YAMLPath]; ok {
+			valueNode.LineComment = source.String()
+		}
+
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: keys[len(keys)-1]}
+		parent.Content = append(parent.Content, keyNode, valueNode)
+	}
+
+	return yaml.Marshal(root)
+}
+
+// set records the Source of the field at the given YAML path.
+func (r Sources) set(yamlPath string, source Source) {
+	if r != nil {
+		r[yamlPath] = source
+	}
+}
+
+// childMappingNode returns the mapping node stored under the key of the parent mapping node, creating it if necessary.
+func childMappingNode(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+
+	return child
+}
+
+// effectiveValueNode encodes the value of a field as YAML node, masking it if the field holds a secret.
+func effectiveValueNode(mapping fieldMapping, value reflect.Value) (*yaml.Node, error) {
+	node := &yaml.Node{}
+
+	if !value.IsValid() {
+		return node, node.Encode(nil)
+	}
+
+	if redacted, ok := redact(mapping, value); ok {
+		return node, node.Encode(redacted)
+	}
+
+	// Encode a pointer, so methods with pointer receivers, e.g. MarshalText, are taken into account.
+	if value.CanAddr() {
+		return node, node.Encode(value.Addr().Interface())
+	}
+
+	return node, node.Encode(value.Interface())
+}
+
+// redact returns the masked representation of a field's value if the field holds a secret.
+// Empty secrets are not masked, so it remains visible that they're not set.
+func redact(mapping fieldMapping, value reflect.Value) (string, bool) {
+	if value.CanAddr() {
+		if redactor, ok := value.Addr().Interface().(Redactor); ok {
+			return redactor.Redact(), true
+		}
+	}
+
+	if mapping.Field.Tag.Get("secret") != "true" {
+		return "", false
+	}
+
+	if value.IsZero() {
+		return "", true
+	}
+
+	return RedactedValue, true
+}
+
+// recordFileSources marks all fields that are present in the YAML node as read from the current configuration file.
+func (r *Config) recordFileSources(config Configure, node *yaml.Node) {
+	if r.Sources == nil {
+		return
+	}
+
+	paths := make(map[string]struct{})
+	collectYAMLPaths(node, "", paths)
+
+	for _, mapping := range fieldMappings(r, config) {
+		if _, ok := paths[mapping.YAMLPath]; ok {
+			r.Sources.set(mapping.YAMLPath, Source{Kind: SourceFile, Name: r.currentFile})
+		}
+	}
+}
+
+// collectYAMLPaths adds the dotted paths of all keys within the YAML node to the set.
+func collectYAMLPaths(node *yaml.Node, prefix string, paths map[string]struct{}) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectYAMLPaths(child, prefix, paths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			path := node.Content[i].Value
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			paths[path] = struct{}{}
+			collectYAMLPaths(node.Content[i+1], path, paths)
+		}
+	default:
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type redactedValue string
+
+func (r *redactedValue) Redact() string {
+	return "<redacted>"
+}
+
+type sourcesConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Password string `secret:"true" yaml:"password"`
+	Token    string `secret:"true" yaml:"token"`
+	Database struct {
+		Name string        `yaml:"name"`
+		Key  redactedValue `yaml:"key"`
+	} `yaml:"database"`
+}
+
+func (r *sourcesConfig) SetDefaults() {
+	r.Host = "127.0.0.1"
+	r.Port = 8080
+}
+
+func (r *sourcesConfig) Validate() error { return nil }
+
+func TestLoadConfigWithSources(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("host: example.com\ndatabase:\n  name: app\n"), 0o600))
+	t.Setenv("APP_PASSWORD", "secret")
+	t.Setenv("DB_KEY", "key")
+
+	config := &sourcesConfig{}
+	sources, err := LoadConfigWithSources(config,
+		WithConfigFilePath(configFile),
+		WithEnvPrefix("APP"),
+		WithEnvAliases(Aliases{"database.key": "db_key"}),
+		WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--port=9090"}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, Sources{
+		"host":          {Kind: SourceFile, Name: configFile},
+		"port":          {Kind: SourceFlag, Name: "--port"},
+		"password":      {Kind: SourceEnv, Name: "APP_PASSWORD"},
+		"token":         {Kind: SourceDefault},
+		"database.name": {Kind: SourceFile, Name: configFile},
+		"database.key":  {Kind: SourceEnvAlias, Name: "DB_KEY"},
+	}, sources)
+}
+
+func TestLoadConfigWithSources_EnvFile(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret\n"), 0o600))
+	t.Setenv("APP_PASSWORD_FILE", secretFile)
+
+	t.Chdir(t.TempDir())
+
+	config := &sourcesConfig{}
+	sources, err := LoadConfigWithSources(config, WithEnvPrefix("APP"), WithEnvFileLoading())
+	require.NoError(t, err)
+	assert.Equal(t, "secret", config.Password)
+	assert.Equal(t, Source{Kind: SourceEnvFile, Name: "APP_PASSWORD_FILE"}, sources["password"])
+}
+
+func TestMarshalEffectiveConfig(t *testing.T) {
+	config := &sourcesConfig{}
+	config.SetDefaults()
+	config.Password = "secret"
+	config.Database.Name = "app"
+	config.Database.Key = "key"
+
+	data, err := MarshalEffectiveConfig(config, Sources{
+		"host":          {Kind: SourceDefault},
+		"port":          {Kind: SourceFlag, Name: "--port"},
+		"password":      {Kind: SourceEnv, Name: "APP_PASSWORD"},
+		"database.name": {Kind: SourceFile, Name: "config.yaml"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `host: 127.0.0.1 # default
+port: 8080 # flag --port
+password: '******' # env APP_PASSWORD
+token: ""
+database:
+    name: app # file config.yaml
+    key: <redacted>
+`, string(data))
+
+	_, err = MarshalEffectiveConfig(nil, nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestLoadConfig_PrintConfig(t *testing.T) {
+	exitCode := -1
+	OsExit = func(code int) {
+		exitCode = code
+	}
+	t.Cleanup(func() {
+		OsExit = os.Exit
+	})
+	t.Chdir(t.TempDir())
+
+	output := &bytes.Buffer{}
+	err := LoadConfig(&sourcesConfig{},
+		WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--print-config", "--host=example.com"}),
+		func(cfg *Config) {
+			cfg.Output = output
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, output.String(), "host: example.com # flag --host\n")
+	assert.Contains(t, output.String(), "port: 8080 # default\n")
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//nolint:gochecknoglobals // This is a type lookup used by reflection and cannot be declared as a constant.
+var yamlUnmarshalerType = reflect.TypeFor[yaml.Unmarshaler]()
+
+// checkUnknownKeys returns an error for every key of the YAML node that does not match a field of the given type.
+// Values decoded by custom unmarshalers or into interfaces are not checked, since they accept arbitrary keys.
+func checkUnknownKeys(node *yaml.Node, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		var errs []error
+		for _, child := range node.Content {
+			errs = append(errs, checkUnknownKeys(child, t))
+		}
+		if node.Alias != nil {
+			errs = append(errs, checkUnknownKeys(node.Alias, t))
+		}
+		return errors.Join(errs...)
+	}
+
+	if reflect.PointerTo(t).Implements(yamlUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	var errs []error
+
+	switch {
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Struct:
+		fields, open := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			if keyNode.Tag == "!!merge" {
+				errs = append(errs, checkUnknownKeys(valueNode, t))
+				continue
+			}
+
+			fieldType, ok := fields[keyNode.Value]
+			switch {
+			case ok:
+				errs = append(errs, checkUnknownKeys(valueNode, fieldType))
+			case !open:
+				errs = append(errs, unknownKeyError(keyNode))
+			}
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Map:
+		for i := 1; i < len(node.Content); i += 2 {
+			errs = append(errs, checkUnknownKeys(node.Content[i], t.Elem()))
+		}
+	case node.Kind == yaml.SequenceNode && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		for _, child := range node.Content {
+			errs = append(errs, checkUnknownKeys(child, t.Elem()))
+		}
+	default:
+	}
+
+	return errors.Join(errs...)
+}
+
+// yamlFields returns the types of the fields of a struct by their YAML key, following the naming rules of yaml.v3.
+// Fields of inlined structs are included. The struct is open, i.e. accepts any key, if it inlines a map.
+func yamlFields(t reflect.Type) (fields map[string]reflect.Type, open bool) {
+	fields = make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(flags, ","), "inline") {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Map {
+				open = true
+				continue
+			}
+			inlined, inlinedOpen := yamlFields(fieldType)
+			for key, value := range inlined {
+				fields[key] = value
+			}
+			open = open || inlinedOpen
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+
+	return fields, open
+}
+
+// unknownKeyError returns an error describing the unknown key and its position.
+func unknownKeyError(keyNode *yaml.Node) error {
+	if keyNode.Line == 0 {
+		return fmt.Errorf("%w %q", ErrUnknownKey, keyNode.Value)
+	}
+
+	return fmt.Errorf("%w %q at line %d, column %d", ErrUnknownKey, keyNode.Value, keyNode.Line, keyNode.Column)
+}
+
+// checkUnknownEnv returns an error listing all environment variables with the EnvPrefix that neither belong to
+// a field nor to an alias. If EnvFileLoading is enabled, the `_FILE` variants of the known names are accepted too.
+func (r *Config) checkUnknownEnv(mappings []fieldMapping) error {
+	if r.EnvPrefix == "" {
+		return nil
+	}
+
+	known := map[string]struct{}{r.environmentVariable(): {}}
+	for _, mapping := range mappings {
+		known[mapping.EnvName] = struct{}{}
+		if mapping.EnvAlias != "" {
+			known[mapping.EnvAlias] = struct{}{}
+		}
+	}
+
+	var unknown []string
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, r.EnvPrefix+"_") {
+			continue
+		}
+
+		if _, ok := known[name]; ok {
+			continue
+		}
+		if _, ok := known[strings.TrimSuffix(name, "_FILE")]; ok && r.EnvFileLoading {
+			continue
+		}
+
+		unknown = append(unknown, name)
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	slices.Sort(unknown)
+
+	return fmt.Errorf("%w: %s", ErrUnknownEnv, strings.Join(unknown, ", "))
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		env     map[string]string
+		opts    []Option
+		wantErr []string
+	}{
+		{
+			name:    "known keys",
+			file:    "config.yaml",
+			content: "host: example.com\nname:\n  first: John\nlabels:\n  any: value\nroles:\n  admin: [read]\n",
+		},
+		{
+			name:    "unknown top-level key",
+			file:    "config.yaml",
+			content: "host: example.com\nprot: 8080\n",
+			wantErr: []string{`unknown key "prot" at line 2, column 1`},
+		},
+		{
+			name:    "unknown nested keys",
+			file:    "config.yaml",
+			content: "name:\n  frist: John\n  lats: Doe\n",
+			wantErr: []string{
+				`unknown key "frist" at line 2, column 3`,
+				`unknown key "lats" at line 3, column 3`,
+			},
+		},
+		{
+			name:    "unknown json key",
+			file:    "config.json",
+			content: "{\n  \"host\": \"example.com\",\n  \"hots\": \"example.com\"\n}\n",
+			wantErr: []string{`unknown key "hots" at line 3, column 3`},
+		},
+		{
+			name:    "unknown env",
+			env:     map[string]string{"STRICT_HOST": "example.com", "STRICT_PROT": "8080", "STRICT_NAME_LAST": "Doe"},
+			wantErr: []string{"unknown environment variables: STRICT_PROT"},
+		},
+		{
+			name: "alias, environment and file variables",
+			env: map[string]string{
+				"STRICT_ENVIRONMENT": "test",
+				"STRICT_PORT_FILE":   "port",
+				"LISTEN_HOST":        "example.com",
+			},
+			opts: []Option{WithEnvFileLoading(), WithEnvAliases(map[string]string{"host": "listen_host"})},
+		},
+		{
+			name:    "file variables without file loading",
+			env:     map[string]string{"STRICT_PORT_FILE": "port"},
+			wantErr: []string{"unknown environment variables: STRICT_PORT_FILE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			require.NoError(t, os.WriteFile("port", []byte("9090"), 0o600))
+			if tt.file != "" {
+				require.NoError(t, os.WriteFile(tt.file, []byte(tt.content), 0o600))
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			opts := append([]Option{WithStrict(), WithEnvPrefix("STRICT")}, tt.opts...)
+			if tt.file != "" {
+				opts = append(opts, WithConfigFilePath(tt.file))
+			}
+
+			err := LoadConfig(&mockConfig{}, opts...)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_NotStrict(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("prot: 8080\n"), 0o600))
+	t.Setenv("STRICT_PROT", "8080")
+
+	require.NoError(t, LoadConfig(&mockConfig{}, WithConfigFilePath(configFile), WithEnvPrefix("STRICT")))
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultExecTimeout is the maximum duration a command referenced by the `!exec` tag may run.
+const DefaultExecTimeout = 10 * time.Second
+
+// TagResolver resolves the value of a YAML scalar with a custom tag, e.g. `!env HOME`, to its actual value.
+type TagResolver func(value string) (string, error)
+
+// TagResolvers maps YAML tags, including the leading exclamation mark, to their TagResolver.
+type TagResolvers map[string]TagResolver
+
+// DefaultTagResolvers returns the built-in resolvers for the `!env` and `!base64` tags.
+func DefaultTagResolvers() TagResolvers {
+	return TagResolvers{
+		"!env":    EnvResolver,
+		"!base64": Base64Resolver,
+	}
+}
+
+// Base64Resolver decodes a standard base64-encoded value (RFC 4648).
+func Base64Resolver(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
+
+// EnvResolver returns the value of the environment variable with the given name.
+func EnvResolver(value string) (string, error) {
+	if envValue, ok := os.LookupEnv(value); ok {
+		return envValue, nil
+	}
+
+	return "", ErrEnvNotSet
+}
+
+// ExecResolver runs the given command without a shell and returns its trimmed standard output.
+// Since the configuration file may execute arbitrary commands with it, the resolver must be registered explicitly,
+// e.g. `WithTagResolver("!exec", ExecResolver)`.
+func ExecResolver(value string) (string, error) {
+	args := strings.Fields(value)
+	if len(args) == 0 {
+		return "", ErrEmptyCommand
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultExecTimeout)
+	defer cancel()
+
+	//nolint:gosec // Executing commands from the configuration file is the purpose of this resolver.
+	output, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// FileResolver returns the content of the file at the given path.
+func FileResolver(value string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(value))
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// SecretResolver returns a TagResolver that looks up secrets by name using the given SecretProvider,
+// e.g. `WithTagResolver("!secret", SecretResolver(NewFileSecretProvider("/run/secrets")))`.
+func SecretResolver(provider SecretProvider) TagResolver {
+	return provider.Secret
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagResolvers(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.URL.Path != "/v1/secret/data/app/name" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"data": {"first": "Jane", "value": "Doe"}}}`))
+	}))
+	defer vault.Close()
+
+	tmpDir := t.TempDir()
+	secretDir := filepath.Join(tmpDir, "secrets")
+	require.NoError(t, os.Mkdir(secretDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(secretDir, "host"), []byte("secret.example.com\n"), 0o600))
+	t.Setenv("TAG_NULL", "null")
+	t.Setenv("TAG_TILDE", "~")
+	t.Setenv("TAG_PORT", "9001")
+
+	tests := []struct {
+		name    string
+		yaml    string
+		opts    []Option
+		wantErr string
+		verify  func(t *testing.T, config *mockConfig)
+	}{
+		{
+			name: "file secret provider",
+			yaml: "host: !secret host\n",
+			opts: []Option{WithTagResolver("secret", SecretResolver(NewFileSecretProvider(secretDir)))},
+			verify: func(t *testing.T, config *mockConfig) {
+				t.Helper()
+				assert.Equal(t, "secret.example.com", config.Host)
+			},
+		},
+		{
+			name:    "file secret provider outside directory",
+			yaml:    "host: !secret ../secrets/host\n",
+			opts:    []Option{WithTagResolver("!secret", SecretResolver(NewFileSecretProvider(secretDir)))},
+			wantErr: "cannot resolve !secret tag at host",
+		},
+		{
+			name: "http secret provider",
+			yaml: "name:\n  first: !vault app/name#first\n  last: !vault app/name\n",
+			opts: []Option{WithTagResolver("!vault", SecretResolver(NewHTTPSecretProvider(vault.URL, "token")))},
+			verify: func(t *testing.T, config *mockConfig) {
+				t.Helper()
+				assert.Equal(t, "Jane", config.Name.First)
+				assert.Equal(t, "Doe", config.Name.Last)
+			},
+		},
+		{
+			name:    "http secret provider missing secret",
+			yaml:    "tags:\n  - !vault app/other\n",
+			opts:    []Option{WithTagResolver("!vault", SecretResolver(NewHTTPSecretProvider(vault.URL, "token")))},
+			wantErr: "cannot resolve !vault tag at tags.0",
+		},
+		{
+			name: "exec resolver",
+			yaml: "port: !exec echo 9000\n",
+			opts: []Option{WithTagResolver("!exec", ExecResolver)},
+			verify: func(t *testing.T, config *mockConfig) {
+				t.Helper()
+				assert.Equal(t, 9000, config.Port)
+			},
+		},
+		{
+			name: "environment variables into strings",
+			yaml: "host: !env TAG_NULL\nport: !env TAG_PORT\ntags:\n  - !env TAG_TILDE\nlabels:\n  team: !env TAG_NULL\n",
+			verify: func(t *testing.T, config *mockConfig) {
+				t.Helper()
+				assert.Equal(t, "null", config.Host)
+				assert.Equal(t, 9001, config.Port)
+				assert.Equal(t, []string{"~"}, config.Tags)
+				assert.Equal(t, map[string]string{"team": "null"}, config.Labels)
+			},
+		},
+		{
+			name:    "unset environment variable",
+			yaml:    "name:\n  first: !env TAG_UNDEFINED\n",
+			wantErr: "cannot resolve !env tag at name.first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(configFile, []byte(tt.yaml), 0o600))
+
+			config := &mockConfig{}
+			err := LoadConfig(config, append(tt.opts, WithConfigFilePath(configFile))...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.verify(t, config)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFromTOML loads configuration data from a TOML byte slice into a Configure object using a Config.
+// The document is converted into a YAML node first, so it is decoded with the same yaml struct tags as YAML files.
+func LoadFromTOML(cfg *Config, config Configure, data []byte) (err error) {
+	var (
+		values map[string]any
+		node   yaml.Node
+	)
+
+	if err = toml.Unmarshal(data, &values); err != nil {
+		return
+	}
+
+	if err = node.Encode(values); err != nil {
+		return
+	}
+
+	return loadFromYAMLNode(cfg, config, &node)
+}
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError describes an invalid value of the configuration.
+type FieldError struct {
+	// Path is the YAML path of the invalid field or nested configuration, e.g. "http.port".
+	Path string
+
+	// Err describes why the value is invalid.
+	Err error
+}
+
+// Error returns the path and the reason, e.g. "http.port: must be between 1 and 65535".
+func (r *FieldError) Error() string {
+	if r.Path == "" {
+		return r.Err.Error()
+	}
+
+	return r.Path + ": " + r.Err.Error()
+}
+
+// Unwrap returns the reason, so it can be matched with errors.Is.
+func (r *FieldError) Unwrap() error {
+	return r.Err
+}
+
+// RangeError describes a value, or the length of a value, that is outside the bounds of its `validate` tag.
+type RangeError struct {
+	// Subject is "length" if the length of the value was checked, otherwise it's empty.
+	Subject string
+
+	// Min is the lower bound, or empty if there is none.
+	Min string
+
+	// Max is the upper bound, or empty if there is none.
+	Max string
+}
+
+// Error returns the violated bounds, e.g. "must be between 1 and 65535".
+func (r *RangeError) Error() string {
+	var message string
+
+	switch {
+	case r.Min != "" && r.Max != "":
+		message = "must be between " + r.Min + " and " + r.Max
+	case r.Min != "":
+		message = "must be at least " + r.Min
+	default:
+		message = "must be at most " + r.Max
+	}
+
+	if r.Subject != "" {
+		return r.Subject + " " + message
+	}
+
+	return message
+}
+
+// SetDefaults calls SetDefaults on every value within the configuration that implements Configure.
+// Nested values are initialized before the values containing them, so a parent can override the defaults of
+// its children. Nil pointers are not allocated.
+func SetDefaults(config Configure) {
+	walkConfigure(reflect.ValueOf(config), "", func(_ string, nested Configure) {
+		nested.SetDefaults()
+	}, nil)
+}
+
+// Validate calls Validate on every value within the configuration that implements Configure and checks the
+// `validate` tags of all fields, e.g. `validate:"required,min=1,max=65535"`.
+// Instead of stopping at the first invalid value, it returns all of them joined as FieldError.
+//
+// Supported rules are `required` (non-zero value), `min=N` and `max=N`. The bounds apply to the value of numbers
+// and durations, e.g. `min=1s`, and to the length of strings, slices and maps.
+func Validate(config Configure) error {
+	var errs []error
+
+	walkConfigure(reflect.ValueOf(config), "", nil, func(path string, value reflect.Value) {
+		if nested, ok := asConfigure(value); ok {
+			if err := nested.Validate(); err != nil {
+				errs = append(errs, &FieldError{Path: path, Err: err})
+			}
+		}
+
+		if value.Kind() != reflect.Struct {
+			return
+		}
+
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			rules, ok := field.Tag.Lookup("validate")
+			if !ok || !field.IsExported() {
+				continue
+			}
+
+			if err := validateField(value.Field(i), rules); err != nil {
+				errs = append(errs, &FieldError{Path: joinPath(path, yamlName(field)), Err: err})
+			}
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// walkConfigure traverses the structs, pointers and slices within the value. The post function is called for
+// every value implementing Configure after its children; the pre function is called for every value before them.
+func walkConfigure(value reflect.Value, path string, post func(string, Configure), pre func(string, reflect.Value)) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	if pre != nil {
+		pre(path, value)
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if path != "" && isLeafType(value.Type()) {
+			break
+		}
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			if !isConfigField(field) {
+				continue
+			}
+			walkConfigure(value.Field(i), joinPath(path, yamlName(field)), post, pre)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			walkConfigure(value.Index(i), joinPath(path, strconv.Itoa(i)), post, pre)
+		}
+	default:
+	}
+
+	if post != nil {
+		if nested, ok := asConfigure(value); ok {
+			post(path, nested)
+		}
+	}
+}
+
+// isConfigField reports whether a struct field is part of the configuration, i.e. it's exported and either
+// has a YAML key or is embedded.
+func isConfigField(field reflect.StructField) bool {
+	if !field.IsExported() {
+		return false
+	}
+
+	tag := field.Tag.Get("yaml")
+
+	return tag != "-" && (tag != "" || field.Anonymous)
+}
+
+// asConfigure returns the value as Configure if its pointer implements the interface.
+func asConfigure(value reflect.Value) (Configure, bool) {
+	if !value.CanAddr() {
+		return nil, false
+	}
+
+	nested, ok := value.Addr().Interface().(Configure)
+
+	return nested, ok
+}
+
+// yamlName returns the YAML key of a struct field. Inlined fields have no key of their own.
+func yamlName(field reflect.StructField) string {
+	name, flags, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" && !strings.Contains(flags, "inline") {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name
+}
+
+// joinPath appends a key to a dotted YAML path.
+func joinPath(path, key string) string {
+	switch {
+	case key == "":
+		return path
+	case path == "":
+		return key
+	default:
+		return path + "." + key
+	}
+}
+
+// validateField checks the value of a field against the comma-separated rules of its `validate` tag.
+func validateField(value reflect.Value, rules string) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			if hasRule(rules, "required") {
+				return ErrRequired
+			}
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	var minBound, maxBound string
+	for rule := range strings.SplitSeq(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "required":
+			if value.IsZero() {
+				return ErrRequired
+			}
+		case "min":
+			minBound = arg
+		case "max":
+			maxBound = arg
+		default:
+			return fmt.Errorf("%w %q", ErrInvalidValidateRule, name)
+		}
+	}
+
+	return validateBounds(value, minBound, maxBound)
+}
+
+// hasRule reports whether the comma-separated rules contain the given rule.
+func hasRule(rules, rule string) bool {
+	for candidate := range strings.SplitSeq(rules, ",") {
+		if strings.TrimSpace(candidate) == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateBounds checks that the value, or the length for strings, slices and maps, is within the given bounds.
+// Empty bounds are not checked.
+func validateBounds(value reflect.Value, minBound, maxBound string) error {
+	if minBound == "" && maxBound == "" {
+		return nil
+	}
+
+	measure, subject := value.Interface(), ""
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		measure, subject = value.Len(), "length"
+	default:
+	}
+
+	lower, err := compareBound(measure, minBound)
+	if err != nil {
+		return err
+	}
+	upper, err := compareBound(measure, maxBound)
+	if err != nil {
+		return err
+	}
+
+	if (minBound != "" && lower < 0) || (maxBound != "" && upper > 0) {
+		return &RangeError{Subject: subject, Min: minBound, Max: maxBound}
+	}
+
+	return nil
+}
+
+// compareBound compares a number or duration with a bound and returns -1, 0 or +1.
+func compareBound(measure any, bound string) (int, error) {
+	if bound == "" {
+		return 0, nil
+	}
+
+	value := reflect.ValueOf(measure)
+	if value.Type() == durationType {
+		limit, err := parseDurationBound(bound)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Int(), limit), nil
+	}
+
+	limit, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidValidateRule, bound, err)
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(float64(value.Int()), limit), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(float64(value.Uint()), limit), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(value.Float(), limit), nil
+	default:
+		return 0, fmt.Errorf("%w: bounds are not supported for %s", ErrInvalidValidateRule, value.Type())
+	}
+}
+
+// parseDurationBound parses a bound of a duration, which is either a duration string or nanoseconds.
+func parseDurationBound(bound string) (int64, error) {
+	if duration, err := strconv.ParseInt(bound, 10, 64); err == nil {
+		return duration, nil
+	}
+
+	duration, err := time.ParseDuration(bound)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidValidateRule, bound, err)
+	}
+
+	return int64(duration), nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNoName = errors.New("name must be set")
+
+type NestedServer struct {
+	Host    string        `validate:"required"         yaml:"host"`
+	Port    int           `validate:"min=1,max=65535"  yaml:"port"`
+	Timeout time.Duration `validate:"min=1s"           yaml:"timeout"`
+	Name    string        `yaml:"name"`
+}
+
+func (r *NestedServer) SetDefaults() {
+	r.Host = "localhost"
+	r.Port = 8080
+	r.Timeout = time.Second
+	r.Name = "server"
+}
+
+func (r *NestedServer) Validate() error {
+	if r.Name == "" {
+		return errNoName
+	}
+
+	return nil
+}
+
+type nestedConfig struct {
+	NestedServer `yaml:",inline"`
+
+	HTTP    NestedServer   `yaml:"http"`
+	Admin   *NestedServer  `yaml:"admin"`
+	Backup  *NestedServer  `yaml:"backup"`
+	Workers []NestedServer `yaml:"workers"`
+	Tags    []string       `validate:"min=1" yaml:"tags"`
+	Ignored NestedServer   `yaml:"-"`
+}
+
+func (r *nestedConfig) SetDefaults() {
+	r.HTTP.Port = 80
+	r.Admin = &NestedServer{}
+	r.Tags = []string{"default"}
+}
+
+func (r *nestedConfig) Validate() error { return nil }
+
+func TestSetDefaults(t *testing.T) {
+	config := &nestedConfig{Backup: &NestedServer{}, Workers: make([]NestedServer, 1)}
+	SetDefaults(config)
+
+	assert.Equal(t, "localhost", config.Host)
+	assert.Equal(t, "localhost", config.HTTP.Host)
+	assert.Equal(t, 80, config.HTTP.Port, "parent overrides defaults of nested values")
+	assert.Equal(t, &NestedServer{}, config.Admin, "pointers allocated by the parent are not initialized")
+	assert.Equal(t, 8080, config.Backup.Port)
+	assert.Equal(t, 8080, config.Workers[0].Port)
+	assert.Equal(t, NestedServer{}, config.Ignored)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(config *nestedConfig)
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			modify: func(_ *nestedConfig) {},
+		},
+		{
+			name: "all invalid values are reported",
+			modify: func(config *nestedConfig) {
+				config.HTTP.Port = 0
+				config.HTTP.Host = ""
+				config.Port = 70000
+				config.Backup.Timeout = time.Millisecond
+				config.Workers[0].Name = ""
+				config.Tags = nil
+			},
+			wantErr: []string{
+				"tags: length must be at least 1",
+				"port: must be between 1 and 65535",
+				"http.host: must be set",
+				"http.port: must be between 1 and 65535",
+				"backup.timeout: must be at least 1s",
+				"workers.0: name must be set",
+			},
+		},
+		{
+			name: "ignored fields are not validated",
+			modify: func(config *nestedConfig) {
+				config.Ignored.Port = -1
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &nestedConfig{Backup: &NestedServer{}, Workers: make([]NestedServer, 1)}
+			SetDefaults(config)
+			*config.Admin = *config.Backup
+			tt.modify(config)
+
+			err := Validate(config)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, strings.Split(err.Error(), "\n"))
+		})
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	err := Validate(&nestedConfig{Workers: make([]NestedServer, 1)})
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.ErrorIs(t, err, ErrRequired)
+	require.ErrorIs(t, err, errNoName)
+
+	var rangeErr *RangeError
+	require.ErrorAs(t, err, &rangeErr)
+}
+
+func TestValidateField(t *testing.T) {
+	flag, name := reflect.ValueOf(true), reflect.ValueOf("a")
+
+	require.ErrorIs(t, validateField(flag, "min=1"), ErrInvalidValidateRule)
+	require.ErrorIs(t, validateField(name, "unknown"), ErrInvalidValidateRule)
+	require.ErrorIs(t, validateField(name, "max=x"), ErrInvalidValidateRule)
+	require.NoError(t, validateField(name, "required,max=1"))
+	require.ErrorIs(t, validateField(reflect.ValueOf((*int)(nil)), "required"), ErrRequired)
+	require.NoError(t, validateField(reflect.ValueOf((*int)(nil)), "min=1"))
+}
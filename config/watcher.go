@@ -0,0 +1,211 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Watcher reloads a configuration whenever one of its files is created, changed, or removed, or the process receives
+// SIGHUP.
+// Every reload runs SetDefaults, the file and environment decoding, and Validate on a fresh copy,
+// which is only published to the subscribers if it's valid. Otherwise, the current configuration is kept.
+// Watcher implements terminator.CallbackTracker.
+type Watcher[T Configure] struct {
+	// cfg holds the settings used to load the configuration.
+	cfg *Config
+
+	// factory creates an empty configuration that is loaded on every reload.
+	factory func() T
+
+	// current is the most recent valid configuration.
+	current T
+
+	// subscribers are notified with every new valid configuration.
+	subscribers []func(T)
+
+	// modTimes contains the modification times of the configuration files at the last reload, including files that
+	// only exist since then.
+	modTimes map[string]time.Time
+
+	// signalCh receives SIGHUP signals.
+	signalCh chan os.Signal
+
+	// stopCh is closed to stop the watching goroutine.
+	stopCh chan struct{}
+
+	// done is a function to signal that the watcher has stopped.
+	done func()
+
+	// mutex protects current, subscribers, and modTimes.
+	mutex sync.RWMutex
+
+	// reloadMutex serializes reloads and lookups of the configuration files, which share cfg.
+	reloadMutex sync.Mutex
+
+	// stopOnce ensures the watcher is only stopped once.
+	stopOnce sync.Once
+}
+
+// NewWatcher loads the initial configuration created by factory and returns a Watcher for it.
+// It fails under the same conditions as LoadConfig.
+func NewWatcher[T Configure](factory func() T, opts ...Option) (*Watcher[T], error) {
+	config := factory()
+
+	cfg, err := newConfig(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take the state of the files before loading them, so changes during the load trigger another reload.
+	files := modTimes(cfg.lookupConfigFiles())
+	if err = cfg.load(config); err != nil {
+		return nil, err
+	}
+
+	return &Watcher[T]{
+		cfg:      cfg,
+		factory:  factory,
+		current:  config,
+		modTimes: files,
+		signalCh: make(chan os.Signal, 1),
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Current returns the most recent valid configuration.
+//
+//nolint:ireturn // The configuration type is defined by the caller.
+func (r *Watcher[T]) Current() T {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.current
+}
+
+// Reload loads a fresh copy of the configuration and publishes it to the subscribers if it's valid.
+func (r *Watcher[T]) Reload() error {
+	r.reloadMutex.Lock()
+	defer r.reloadMutex.Unlock()
+
+	config := r.factory()
+	files := modTimes(r.cfg.lookupConfigFiles())
+	err := r.cfg.load(config)
+
+	r.mutex.Lock()
+	// Remember the state of the files either way, so an invalid edit is only reported once.
+	r.modTimes = files
+	if err != nil {
+		r.mutex.Unlock()
+		r.cfg.Log.Warnf("config reload failed, keeping current configuration: %v", err)
+
+		return err
+	}
+	r.current = config
+	subscribers := append([]func(T){}, r.subscribers...)
+	r.mutex.Unlock()
+
+	r.cfg.Log.Infof("config reloaded from %v", r.cfg.ConfigFiles)
+
+	for _, subscriber := range subscribers {
+		subscriber(config)
+	}
+
+	return nil
+}
+
+// Start begins watching the configuration files and SIGHUP signals until the context is canceled.
+func (r *Watcher[T]) Start(ctx context.Context, done func()) error {
+	r.done = done
+
+	signal.Notify(r.signalCh, syscall.SIGHUP)
+
+	go r.watch()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.Stop()
+		case <-r.stopCh:
+		}
+	}()
+
+	return nil
+}
+
+// Stop halts watching the configuration.
+func (r *Watcher[T]) Stop() {
+	r.stopOnce.Do(func() {
+		signal.Stop(r.signalCh)
+		close(r.stopCh)
+
+		if r.done != nil {
+			r.done()
+		}
+	})
+}
+
+// Subscribe registers a function that is called with every new valid configuration.
+func (r *Watcher[T]) Subscribe(subscriber func(T)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.subscribers = append(r.subscribers, subscriber)
+}
+
+// changed reports whether any configuration file was created, modified, or removed since the last reload.
+func (r *Watcher[T]) changed() bool {
+	r.reloadMutex.Lock()
+	files := modTimes(r.cfg.lookupConfigFiles())
+	r.reloadMutex.Unlock()
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(files) != len(r.modTimes) {
+		return true
+	}
+
+	for filePath, modTime := range r.modTimes {
+		if current, ok := files[filePath]; !ok || !current.Equal(modTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watch polls the configuration files and listens for SIGHUP until the watcher is stopped.
+func (r *Watcher[T]) watch() {
+	ticker := time.NewTicker(r.cfg.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-r.signalCh:
+			r.cfg.Log.Info("config reload triggered by SIGHUP")
+			_ = r.Reload()
+		case <-ticker.C:
+			if r.changed() {
+				_ = r.Reload()
+			}
+		}
+	}
+}
+
+// modTimes returns the modification times of the given files. Files that do not exist are omitted.
+func modTimes(files []string) map[string]time.Time {
+	result := make(map[string]time.Time, len(files))
+	for _, filePath := range files {
+		if info, err := os.Stat(filePath); err == nil {
+			result[filePath] = info.ModTime()
+		}
+	}
+
+	return result
+}
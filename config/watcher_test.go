@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.yaml")
+	writeConfig := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(configFile, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(configFile, modTime, modTime))
+	}
+	writeConfig("host: first.example.com\n", time.Now().Add(-time.Hour))
+
+	watcher, err := NewWatcher(
+		func() *mockConfig { return &mockConfig{} },
+		WithConfigFilePath(configFile),
+		WithWatchInterval(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "first.example.com", watcher.Current().Host)
+
+	updates := make(chan *mockConfig, 1)
+	watcher.Subscribe(func(config *mockConfig) {
+		updates <- config
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	require.NoError(t, watcher.Start(ctx, func() { close(stopped) }))
+
+	writeConfig("host: second.example.com\n", time.Now().Add(-time.Minute))
+	select {
+	case config := <-updates:
+		assert.Equal(t, "second.example.com", config.Host)
+		assert.Equal(t, 8080, config.Port)
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for config reload")
+	}
+
+	writeConfig("port: invalid\n", time.Now())
+	require.Error(t, watcher.Reload())
+	assert.Equal(t, "second.example.com", watcher.Current().Host)
+	assert.Empty(t, updates)
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for watcher to stop")
+	}
+}
+
+func TestWatcher_NewFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("host: base.example.com\n"), 0o600))
+	t.Setenv("WATCHER_TEST_ENVIRONMENT", "staging")
+
+	watcher, err := NewWatcher(
+		func() *mockConfig { return &mockConfig{} },
+		WithConfigFilePath(configFile),
+		WithLayeredFiles(),
+		WithEnvironmentVariable("WATCHER_TEST_ENVIRONMENT"),
+		WithWatchInterval(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	updates := make(chan *mockConfig, 1)
+	watcher.Subscribe(func(config *mockConfig) {
+		updates <- config
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, watcher.Start(ctx, func() {}))
+
+	// Files are renamed into place, so a poll never sees them half written.
+	writeFile := func(filePath, content string) {
+		require.NoError(t, os.WriteFile(filePath+".tmp", []byte(content), 0o600))
+		require.NoError(t, os.Rename(filePath+".tmp", filePath))
+	}
+	waitForUpdate := func() *mockConfig {
+		select {
+		case config := <-updates:
+			return config
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for config reload")
+			return nil
+		}
+	}
+
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, ConfigFragmentsDir), 0o700))
+	fragment := filepath.Join(tmpDir, ConfigFragmentsDir, "10-port.yaml")
+	writeFile(fragment, "port: 7000\n")
+	config := waitForUpdate()
+	assert.Equal(t, "base.example.com", config.Host)
+	assert.Equal(t, 7000, config.Port)
+
+	overlay := filepath.Join(tmpDir, "config.staging.yaml")
+	writeFile(overlay, "host: staging.example.com\n")
+	config = waitForUpdate()
+	assert.Equal(t, "staging.example.com", config.Host)
+	assert.Equal(t, 7000, config.Port)
+
+	require.NoError(t, os.Remove(fragment))
+	assert.Equal(t, 8080, waitForUpdate().Port)
+}
@@ -1,8 +1,9 @@
 package config
 
 import (
-	"os"
-	"path/filepath"
+	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -15,49 +16,126 @@ type yamlDecoder struct {
 // LoadFromYAML loads configuration data from a YAML byte slice into a Configure object using a Config.
 func LoadFromYAML(cfg *Config, config Configure, data []byte) (err error) {
 	var node yaml.Node
-	decoder := yamlDecoder{cfg: cfg}
 
 	if err = yaml.Unmarshal(data, &node); err != nil {
 		return
 	}
 
-	if cfg.YAMLFileLoading {
-		if err = decoder.processYAMLNode(&node); err != nil {
+	return loadFromYAMLNode(cfg, config, &node)
+}
+
+// loadFromYAMLNode decodes a parsed YAML node into a Configure object.
+// All file formats are decoded through a YAML node, so the yaml struct tags define the key names for every format.
+// In strict mode, keys that don't match a field are rejected.
+func loadFromYAMLNode(cfg *Config, config Configure, node *yaml.Node) (err error) {
+	decoder := yamlDecoder{cfg: cfg}
+
+	if len(cfg.TagResolvers) > 0 {
+		if err = decoder.processYAMLNode(node, nil, reflect.TypeOf(config)); err != nil {
 			return
 		}
 	}
 
-	return node.Decode(config)
-}
-
-// processYAMLNode recursively processes a YAML node for file loading and nested content handling based on the context.
-func (r *yamlDecoder) processYAMLNode(node *yaml.Node) (err error) {
-	if r.cfg.YAMLFileLoading {
-		err = r.processYAMLFileLoading(node)
-		if err != nil {
+	if cfg.Strict {
+		if err = checkUnknownKeys(node, reflect.TypeOf(config)); err != nil {
 			return
 		}
 	}
 
-	for _, child := range node.Content {
-		err = r.processYAMLNode(child)
-		if err != nil {
-			return err
-		}
+	if err = node.Decode(config); err != nil {
+		return
 	}
 
+	cfg.recordFileSources(config, node)
+
 	return
 }
 
-// processYAMLFileLoading processes a YAML node, replaces scalar file references with file contents if tagged as `!file`.
-func (*yamlDecoder) processYAMLFileLoading(node *yaml.Node) error {
-	if node.Kind == yaml.ScalarNode && node.Tag == "!file" {
-		filePath := strings.TrimSpace(node.Value)
-		content, err := os.ReadFile(filepath.Clean(filePath))
-		if err != nil {
-			return err
+// processYAMLNode recursively processes a YAML node and resolves custom tags of its scalar nodes.
+// The path of keys and indexes leading to the node is used in error messages, and the type the node is decoded into
+// selects how resolved values are interpreted. The type is nil if it's unknown, e.g. for keys without a field.
+func (r *yamlDecoder) processYAMLNode(node *yaml.Node, path []string, t reflect.Type) (err error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if err = r.processYAMLTag(node, path, t); err != nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		var fields map[string]reflect.Type
+		if t != nil && t.Kind() == reflect.Struct {
+			fields, _ = yamlFields(t)
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			childPath := append(path, keyNode.Value) //nolint:gocritic // A new slice is intended.
+
+			var childType reflect.Type
+			switch {
+			case keyNode.Tag == "!!merge":
+				childType = t
+			case fields != nil:
+				childType = fields[keyNode.Value]
+			case t != nil && t.Kind() == reflect.Map:
+				childType = t.Elem()
+			}
+
+			if err = r.processYAMLNode(node.Content[i+1], childPath, childType); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		var childType reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			childType = t.Elem()
+		}
+
+		for i, child := range node.Content {
+			childPath := append(path, strconv.Itoa(i)) //nolint:gocritic // A new slice is intended.
+			if err = r.processYAMLNode(child, childPath, childType); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, child := range node.Content {
+			if err = r.processYAMLNode(child, path, t); err != nil {
+				return err
+			}
 		}
-		node.Value = string(content)
 	}
+
+	return
+}
+
+// processYAMLTag replaces the value of a scalar node with the result of the TagResolver registered for its tag,
+// e.g. `!file` with the content of the referenced file. If the node is decoded into a string or a
+// encoding.TextUnmarshaler, the resolved value is tagged as string, so e.g. an empty value or `null` is kept as is.
+// Otherwise, the tag is removed, so the resolved value is interpreted like an untagged value, e.g. as number or bool.
+func (r *yamlDecoder) processYAMLTag(node *yaml.Node, path []string, t reflect.Type) error {
+	if node.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	resolver, ok := r.cfg.TagResolvers[node.Tag]
+	if !ok {
+		return nil
+	}
+
+	value, err := resolver(strings.TrimSpace(node.Value))
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s tag at %s: %w", node.Tag, strings.Join(path, "."), err)
+	}
+
+	node.Value = value
+	node.Tag = ""
+
+	if t != nil && (t.Kind() == reflect.String || reflect.PointerTo(t).Implements(textUnmarshalerType)) {
+		node.Tag = "!!str"
+	}
+
 	return nil
 }
@@ -12,7 +12,7 @@ var _ config.Configure = (*Config)(nil)
 // Config holds configuration related to user and API key authentication.
 type Config struct {
 	// Tokens list representing API keys that can be used to authenticate requests.
-	Tokens []string `json:"tokens" mapstructure:"tokens" yaml:"tokens"`
+	Tokens []config.Secret `json:"tokens" mapstructure:"tokens" yaml:"tokens"`
 
 	// Authenticators is a list of authenticators that can be used to authenticate requests.
 	Authenticators []Authenticator
@@ -21,19 +21,19 @@ type Config struct {
 	Repository Repository
 
 	// Principals is a map of principal ids to passwords.
-	Principals map[string]string `json:"principals" mapstructure:"principals" yaml:"principals"`
+	Principals map[string]config.Secret `json:"principals" mapstructure:"principals" yaml:"principals"`
 
 	JWT *jwt.Config `json:"jwt" mapstructure:"jwt" yaml:"jwt"`
 }
 
 // SetDefaults initializes the default values for the relevant fields in the struct.
 func (r *Config) SetDefaults() {
-	r.Tokens = []string{}
+	r.Tokens = []config.Secret{}
 	r.Authenticators = []Authenticator{
 		NewTokenAuthenticator(r),
 	}
 	r.Repository = NewConfigRepository(r)
-	r.Principals = map[string]string{}
+	r.Principals = map[string]config.Secret{}
 	r.JWT = &jwt.Config{}
 	r.JWT.SetDefaults()
 }
@@ -44,7 +44,7 @@ func (r *Config) Validate() error {
 		return ErrInvalidTokens
 	}
 
-	if slices.Contains(r.Tokens, "") {
+	if slices.ContainsFunc(r.Tokens, config.Secret.IsEmpty) {
 		return ErrEmptyToken
 	}
 
@@ -75,7 +75,7 @@ func (r *Config) Validate() error {
 	}
 
 	for i := range r.Principals {
-		if r.Principals[i] == "" {
+		if r.Principals[i].IsEmpty() {
 			return ErrEmptyPassword
 		}
 	}
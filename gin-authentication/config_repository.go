@@ -17,7 +17,7 @@ func NewConfigRepository(cfg *Config) *ConfigRepository {
 //nolint:ireturn // Principal is implemented by the repository.
 func (r *ConfigRepository) GetByCredentials(id, password string) (Principal, error) {
 	if passwd, ok := r.cfg.Principals[id]; ok {
-		err := CompareSecrets(passwd, password)
+		err := CompareSecrets(string(passwd.Reveal()), password)
 		if err != nil {
 			return nil, err
 		}
@@ -33,7 +33,7 @@ func (r *ConfigRepository) GetByCredentials(id, password string) (Principal, err
 //nolint:ireturn // Principal is implemented by the repository.
 func (r *ConfigRepository) GetByToken(token string) (Principal, error) {
 	for i := range r.cfg.Tokens {
-		err := CompareSecrets(r.cfg.Tokens[i], token)
+		err := CompareSecrets(string(r.cfg.Tokens[i].Reveal()), token)
 		if err == nil {
 			return tokenPrincipal, nil
 		}
@@ -8,6 +8,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/spacecafe/gobox/config v0.0.0-20251028094851-e45d7f69d144
 	github.com/spacecafe/gobox/gin-problems v0.0.0-20251028094851-e45d7f69d144
+	github.com/spacecafe/gobox/logger v0.0.0-20251028094851-e45d7f69d144
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/crypto v0.43.0
 )
@@ -37,7 +38,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/quic-go/qpack v0.5.1 // indirect
 	github.com/quic-go/quic-go v0.55.0 // indirect
-	github.com/spacecafe/gobox/logger v0.0.0-20251028094851-e45d7f69d144 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/ugorji/go/codec v1.3.1 // indirect
 	go.uber.org/mock v0.6.0 // indirect
@@ -18,11 +18,13 @@ var (
 
 // Config holds configuration related to JWT.
 type Config struct {
-	// Secret as a base64 encoded string (RFC 4648) is used to generate and validate access tokens.
-	Secret Secret `json:"secret" mapstructure:"secret" yaml:"secret"`
+	// Secret is used to generate and validate access tokens. Binary keys can be given base64-encoded
+	// with the prefix `base64:`.
+	Secret config.Secret `json:"secret" mapstructure:"secret" yaml:"secret"`
 
-	// RefreshSecret as a base64 encoded string (RFC 4648) is used to generate and validate refresh tokens.
-	RefreshSecret Secret `json:"refreshSecret" mapstructure:"refresh-secret" yaml:"refreshSecret"`
+	// RefreshSecret is used to generate and validate refresh tokens. Binary keys can be given base64-encoded
+	// with the prefix `base64:`.
+	RefreshSecret config.Secret `json:"refreshSecret" mapstructure:"refresh-secret" yaml:"refreshSecret"`
 
 	// Audience is the intended recipient of the token.
 	// It is usually a list of URLs of the services that can consume the token.
@@ -66,12 +68,12 @@ func (r *Config) SetDefaults() {
 // Validate ensures the all necessary configurations are filled and within valid confines.
 func (r *Config) Validate() error {
 	//nolint:mnd // Minimum length of 32 is required for HS256.
-	if len(r.Secret) < 32 {
+	if r.Secret.Len() < 32 {
 		return ErrInvalidSecret
 	}
 
 	//nolint:mnd // Minimum length of 32 is required for HS256.
-	if len(r.RefreshSecret) < 32 {
+	if r.RefreshSecret.Len() < 32 {
 		return ErrInvalidSecret
 	}
 
@@ -157,8 +157,8 @@ func (r *Token) cookieName() string {
 // secret returns the secret bytes used for signing the token based on its type.
 func (r *Token) secret() []byte {
 	if r.tokenType == RefreshToken {
-		return r.cfg.RefreshSecret.Bytes()
+		return r.cfg.RefreshSecret.Reveal()
 	}
 
-	return r.cfg.Secret.Bytes()
+	return r.cfg.Secret.Reveal()
 }
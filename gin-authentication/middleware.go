@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spacecafe/gobox/logger"
 )
 
 const (
@@ -11,6 +12,8 @@ const (
 )
 
 // New returns a gin.HandlerFunc that authenticates requests based on the provided configuration.
+// The ID of the principal is stored in the context of the request, so it's added to the log entries written with
+// logger.FromContext.
 func New(cfg *Config) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var i int
@@ -18,6 +21,9 @@ func New(cfg *Config) gin.HandlerFunc {
 			principal, err := cfg.Authenticators[i].Authenticate(ctx)
 			if err == nil && principal != nil {
 				ctx.Set(PrincipalContextKey, principal)
+				ctx.Request = ctx.Request.WithContext(
+					logger.ContextWithID(ctx.Request.Context(), logger.PrincipalIDKey, principal.ID()),
+				)
 				ctx.Next()
 
 				return
@@ -8,9 +8,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	jwt2 "github.com/golang-jwt/jwt/v5"
+	"github.com/spacecafe/gobox/config"
 	authentication "github.com/spacecafe/gobox/gin-authentication"
 	"github.com/spacecafe/gobox/gin-authentication/jwt"
 	problems "github.com/spacecafe/gobox/gin-problems"
+	"github.com/spacecafe/gobox/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,11 +33,17 @@ func (r *MockPrincipal) Name() string {
 // setupTestConfig creates a test configuration with all necessary defaults.
 func setupTestConfig() *authentication.Config {
 	cfg := &authentication.Config{
-		Tokens:     []string{"valid-token", "another-token"},
-		Principals: map[string]string{"user1": "password1", "user2": "password2"},
+		Tokens: []config.Secret{
+			config.NewSecret([]byte("valid-token")),
+			config.NewSecret([]byte("another-token")),
+		},
+		Principals: map[string]config.Secret{
+			"user1": config.NewSecret([]byte("password1")),
+			"user2": config.NewSecret([]byte("password2")),
+		},
 		JWT: &jwt.Config{
-			Secret:            jwt.Secret("this-is-a-test-secret-min-32-chars"),
-			RefreshSecret:     jwt.Secret("this-is-a-test-refresh-secret-min-32-chars"),
+			Secret:            config.NewSecret([]byte("this-is-a-test-secret-min-32-chars")),
+			RefreshSecret:     config.NewSecret([]byte("this-is-a-test-refresh-secret-min-32-chars")),
 			Audience:          []string{"test-audience"},
 			Issuer:            "test-issuer",
 			CookieName:        "__Host-access_token",
@@ -600,6 +608,7 @@ func TestNew_PrincipalSetInContext(t *testing.T) {
 		require.NotNil(t, principal, "principal should not be nil")
 		assert.Equal(t, "token", principal.ID())
 		assert.Equal(t, "token", principal.Name())
+		assert.Equal(t, "token", logger.IDFromContext(ctx.Request.Context(), logger.PrincipalIDKey))
 		ctx.JSON(http.StatusOK, gin.H{"success": true})
 	})
 
@@ -19,7 +19,7 @@ var (
 // Config holds configuration related to CSRF protection.
 type Config struct {
 	// Secret is used to generate and validate CSRF tokens.
-	Secret Secret `json:"secret" mapstructure:"secret" yaml:"secret"`
+	Secret config.Secret `json:"secret" mapstructure:"secret" yaml:"secret"`
 
 	// CookieName is the name of the cookie where the CSRF token will be stored.
 	CookieName string `json:"cookieName" mapstructure:"cookie-name" yaml:"cookieName"`
@@ -44,7 +44,7 @@ func (r *Config) SetDefaults() {
 // Validate ensures the all necessary configurations are filled and within valid confines.
 func (r *Config) Validate() error {
 	//nolint:mnd // Minimum length of 32 is required for HS256.
-	if len(r.Secret) < 32 {
+	if r.Secret.Len() < 32 {
 		return ErrInvalidSecret
 	}
 
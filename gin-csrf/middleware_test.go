@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spacecafe/gobox/config"
 	authentication "github.com/spacecafe/gobox/gin-authentication"
 	csrf "github.com/spacecafe/gobox/gin-csrf"
 	problems "github.com/spacecafe/gobox/gin-problems"
@@ -45,7 +46,7 @@ func (r *MockSession) SessionID() string {
 
 func setupTestConfig() *csrf.Config {
 	cfg := &csrf.Config{
-		Secret:     csrf.Secret("this-is-a-test-secret-min-32-chars"),
+		Secret:     config.NewSecret([]byte("this-is-a-test-secret-min-32-chars")),
 		CookieName: "csrf-token",
 		HeaderName: "X-CSRF-Token",
 		Signer:     sha256.New,
@@ -109,7 +109,7 @@ func (r *Token) HMAC() []byte {
 	msg.WriteByte('!')
 	msg.Write(r.random)
 
-	hash := hmac.New(r.cfg.Signer, r.cfg.Secret)
+	hash := hmac.New(r.cfg.Signer, r.cfg.Secret.Reveal())
 	hash.Write(msg.Bytes())
 
 	return hash.Sum(nil)
@@ -19,4 +19,5 @@ var (
 	ErrNoContext          = errors.New("http-server context can not be empty")
 	ErrNoHost             = errors.New("http-server host cannot be empty")
 	ErrNoKeyFile          = errors.New("http-server cert file is set but key-file is empty")
+	ErrPanicRecovered     = errors.New("http-server recovered from panic")
 )
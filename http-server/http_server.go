@@ -62,7 +62,7 @@ func New(cfg *Config, log logger.ConfigurableLogger) *HTTPServer {
 		engine.Use(NewGinLogger(log))
 	}
 
-	engine.Use(gin.Recovery(), problems.New())
+	engine.Use(NewGinRecovery(log), problems.New())
 	server.SetEngine(engine)
 
 	// Enables the server to handle 'Method Not Allowed' errors by returning `405` status code.
@@ -97,7 +97,7 @@ func (r *HTTPServer) SetEngine(engine *gin.Engine) {
 
 // Start function starts the HTTP server in a separate goroutine.
 func (r *HTTPServer) Start(ctx context.Context, done func()) error {
-	r.log.Infof("starting web server and listen to %s", r.server.Addr)
+	r.log.With("address", r.server.Addr).Info("starting web server")
 
 	if ctx == nil {
 		return ErrNoContext
@@ -144,10 +144,10 @@ func (r *HTTPServer) Stop() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	r.log.Infof("stopping http-server at '%s'", r.server.Addr)
+	r.log.With("address", r.server.Addr).Info("stopping http-server")
 
 	err := r.server.Shutdown(ctx)
 	if err != nil {
-		r.log.Warnf("shutdown of http-server was unsuccessful: %s", err)
+		r.log.With("address", r.server.Addr, "error", err).Warn("shutdown of http-server was unsuccessful")
 	}
 }
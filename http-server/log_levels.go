@@ -0,0 +1,50 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	problems "github.com/spacecafe/gobox/gin-problems"
+	"github.com/spacecafe/gobox/logger"
+)
+
+// LogLevelsPath is the path of the handlers registered by RegisterLogLevels.
+const LogLevelsPath = "/admin/log-levels"
+
+// RegisterLogLevels registers GetLogLevels and PutLogLevels at LogLevelsPath, e.g. on HTTPServer.Router.
+// The handlers change the verbosity of the whole application, so the routes must be protected, e.g. by an
+// authentication middleware.
+func RegisterLogLevels(router gin.IRoutes) {
+	router.GET(LogLevelsPath, GetLogLevels)
+	router.PUT(LogLevelsPath, PutLogLevels)
+}
+
+// GetLogLevels responds with the effective, configured and overridden levels of all component loggers.
+func GetLogLevels(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, logger.ComponentLevels())
+}
+
+// PutLogLevels overrides the levels of component loggers at runtime. The body maps component names to levels,
+// e.g. {"job-manager": "debug", "*": null}, where null removes the override and "*" applies to all loggers.
+// It responds like GetLogLevels.
+func PutLogLevels(ctx *gin.Context) {
+	var overrides map[string]*logger.Level
+	if err := ctx.ShouldBindJSON(&overrides); err != nil {
+		problems.ProblemBadRequest.WithDetail(err.Error()).Abort(ctx)
+		return
+	}
+
+	for name, level := range overrides {
+		if level == nil {
+			logger.ResetLevelOverride(name)
+			continue
+		}
+
+		if err := logger.SetLevelOverride(name, *level); err != nil {
+			problems.ProblemInvalidArgument.WithDetail(err.Error()).Abort(ctx)
+			return
+		}
+	}
+
+	GetLogLevels(ctx)
+}
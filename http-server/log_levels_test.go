@@ -0,0 +1,67 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	problems "github.com/spacecafe/gobox/gin-problems"
+	httpserver "github.com/spacecafe/gobox/http-server"
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevels(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(problems.New())
+	httpserver.RegisterLogLevels(engine)
+
+	logger.New(logger.WithLevel(logger.WarnLevel)).Named("test-http")
+	t.Cleanup(func() {
+		logger.ResetLevelOverride("test-http")
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		status    int
+		wantLevel string
+	}{
+		{"list", http.MethodGet, "", http.StatusOK, "warn"},
+		{"override", http.MethodPut, `{"test-http": "debug"}`, http.StatusOK, "debug"},
+		{"invalid level", http.MethodPut, `{"test-http": "verbose"}`, http.StatusBadRequest, "debug"},
+		{"reset", http.MethodPut, `{"test-http": null}`, http.StatusOK, "warn"},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(tt.method, httpserver.LogLevelsPath, strings.NewReader(tt.body))
+		request.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(recorder, request)
+		require.Equal(t, tt.status, recorder.Code, tt.name)
+
+		for _, level := range logger.ComponentLevels() {
+			if level.Name == "test-http" {
+				assert.Equal(t, tt.wantLevel, level.Level.String(), tt.name)
+			}
+		}
+
+		if tt.status != http.StatusOK {
+			continue
+		}
+
+		var levels []logger.ComponentLevel
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &levels), tt.name)
+		assert.True(t, slices.ContainsFunc(levels, func(level logger.ComponentLevel) bool {
+			return level.Name == "test-http" && level.Level.String() == tt.wantLevel
+		}), tt.name)
+	}
+}
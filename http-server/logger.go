@@ -1,6 +1,11 @@
 package httpserver
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -10,6 +15,18 @@ import (
 	"github.com/spacecafe/gobox/logger"
 )
 
+const (
+	// RequestIDHeader is the header that carries the ID of a request. An ID sent by the client or a proxy is
+	// kept, otherwise one is generated. It's returned in the response.
+	RequestIDHeader = "X-Request-ID"
+
+	// TraceparentHeader is the W3C Trace Context header that carries the trace ID and the parent span ID.
+	TraceparentHeader = "traceparent"
+
+	// maxRequestIDSize limits the size of request IDs sent by clients.
+	maxRequestIDSize = 128
+)
+
 var (
 	// ColoredStatusCodes is used to set the color and format of HTTP status codes.
 	//nolint:gochecknoglobals // This is a lookup map that needs to be globally accessible.
@@ -239,7 +256,29 @@ func (r *LogEntry) String() string {
 	return builder.String()
 }
 
+// Fields returns the details of the log entry as logger fields, named like their JSON keys.
+func (r *LogEntry) Fields() logger.Fields {
+	fields := logger.Fields{
+		"clientIP":   r.ClientIP,
+		"latency":    r.Latency,
+		"method":     r.Method,
+		"path":       r.Path,
+		"size":       r.Size,
+		"statusCode": r.StatusCode,
+	}
+
+	if r.Errors != "" {
+		fields["errors"] = r.Errors
+	}
+
+	return fields
+}
+
 // NewGinLogger creates a gin.HandlerFunc that logs HTTP request details using the provided logger.
+// The details are attached as fields, see LogEntry.Fields, and the message is the method and path of the request.
+//
+// The logger, the request ID and the trace IDs of the traceparent header are stored in the context of the request,
+// so handlers can log with them by logger.FromContext(ctx.Request.Context()), see NewRequestContext.
 func NewGinLogger(log logger.Logger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// Start timer.
@@ -247,6 +286,9 @@ func NewGinLogger(log logger.Logger) gin.HandlerFunc {
 		path := ctx.Request.URL.Path
 		raw := ctx.Request.URL.RawQuery
 
+		ctx.Request = ctx.Request.WithContext(NewRequestContext(ctx.Request, log))
+		ctx.Header(RequestIDHeader, logger.IDFromContext(ctx.Request.Context(), logger.RequestIDKey))
+
 		// Process request.
 		ctx.Next()
 
@@ -265,6 +307,83 @@ func NewGinLogger(log logger.Logger) gin.HandlerFunc {
 		}
 
 		entry.Path = path
-		log.Info(entry)
+
+		// The context of the request may have been extended by later handlers, e.g. with the principal ID.
+		logger.FromContext(ctx.Request.Context()).WithFields(entry.Fields()).Infof("%s %s", entry.Method, entry.Path)
 	}
 }
+
+// NewGinRecovery creates a gin.HandlerFunc that recovers from panics of later handlers, logs them as errors
+// wrapping ErrPanicRecovered with the IDs stored in the context of the request and aborts with status 500.
+// The stack trace of the panic and the error chain are logged if the logger captures them, see
+// logger.WithStackTrace. Broken connections are not logged, like by gin.Recovery.
+func NewGinRecovery(log logger.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, recovered any) {
+		err, ok := recovered.(error)
+		if !ok {
+			err = errors.New(fmt.Sprint(recovered)) //nolint:err113 // The panic value is not an error.
+		}
+
+		log.WithFields(logger.ContextFields(ctx.Request.Context())).Error(fmt.Errorf("%w: %w", ErrPanicRecovered, err))
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// NewRequestContext returns a copy of the context of the request that carries the logger, the request ID of the
+// RequestIDHeader or a generated one, and the trace and span ID of the TraceparentHeader, if valid.
+func NewRequestContext(request *http.Request, log logger.Logger) context.Context {
+	ctx := logger.NewContext(request.Context(), log)
+
+	requestID := request.Header.Get(RequestIDHeader)
+	if !isValidRequestID(requestID) {
+		requestID = rand.Text()
+	}
+	ctx = logger.ContextWithID(ctx, logger.RequestIDKey, requestID)
+
+	if traceID, spanID, ok := parseTraceparent(request.Header.Get(TraceparentHeader)); ok {
+		ctx = logger.ContextWithID(ctx, logger.TraceIDKey, traceID)
+		ctx = logger.ContextWithID(ctx, logger.SpanIDKey, spanID)
+	}
+
+	return ctx
+}
+
+// isValidRequestID reports whether the request ID is short and consists of characters that are safe to log.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDSize {
+		return false
+	}
+
+	return !strings.ContainsFunc(id, func(char rune) bool {
+		return !(char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' ||
+			strings.ContainsRune("-_.:", char))
+	})
+}
+
+// parseTraceparent returns the trace ID and the parent span ID of a traceparent header like
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", see https://www.w3.org/TR/trace-context/.
+func parseTraceparent(header string) (string, string, bool) {
+	parts := strings.Split(header, "-")
+	//nolint:mnd // The header consists of version, trace ID, parent ID and flags.
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+
+	traceID, spanID := parts[1], parts[2]
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) { //nolint:mnd // Sizes of the IDs in hex.
+		return "", "", false
+	}
+
+	return traceID, spanID, true
+}
+
+// isHexID reports whether the ID consists of the given number of lowercase hex digits and not only zeros.
+func isHexID(id string, size int) bool {
+	if len(id) != size || strings.ToLower(id) != id || strings.Trim(id, "0") == "" {
+		return false
+	}
+
+	_, err := hex.DecodeString(id)
+
+	return err == nil
+}
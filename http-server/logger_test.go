@@ -1,10 +1,14 @@
 package httpserver_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"net/http/httptest"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -36,25 +40,146 @@ func TestLogger(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			var buf bytes.Buffer
 			log := logger.New()
-			logfile := filepath.Join(t.TempDir(), "test.log")
-			err := log.SetOutput(logfile)
-			require.NoError(t, err)
+			log.SetOutput(&buf)
 
-			err = os.Truncate(logfile, 0)
-			require.NoError(t, err)
-
-			ctx, _ := gin.CreateTestContext(nil)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
 			ctx.Request, _ = http.NewRequestWithContext(ctx, tt.method, tt.url, http.NoBody)
 			ctx.Status(tt.status)
 			httpserver.NewGinLogger(log)(ctx)
 
-			content, err := os.ReadFile(logfile)
-			require.NoError(t, err)
+			content := buf.String()
 			assert.NotEmpty(t, content)
-			assert.Contains(t, string(content), strconv.Itoa(tt.status))
-			assert.Contains(t, string(content), tt.method)
-			assert.Contains(t, string(content), tt.url)
+			assert.Contains(t, content, "statusCode="+strconv.Itoa(tt.status))
+			assert.Contains(t, content, "method="+tt.method)
+			assert.Contains(t, content, tt.url)
+		})
+	}
+}
+
+func TestLogger_RequestContext(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		requestID     string
+		traceparent   string
+		wantRequestID string
+		wantTraceID   string
+		wantSpanID    string
+	}{
+		{
+			name:          "request and trace IDs",
+			requestID:     "request-1",
+			traceparent:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantRequestID: "request-1",
+			wantTraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpanID:    "00f067aa0ba902b7",
+		},
+		{name: "generated request ID"},
+		{name: "invalid request ID", requestID: "request 1\n"},
+		{name: "invalid traceparent", traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(logger.WithFormat(logger.JSONFormat))
+			log.SetOutput(&buf)
+
+			engine := gin.New()
+			engine.Use(httpserver.NewGinLogger(log))
+			engine.GET("/jobs", func(ctx *gin.Context) {
+				logger.FromContext(ctx.Request.Context()).Info("handling request")
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/jobs", http.NoBody)
+			if tt.requestID != "" {
+				request.Header.Set(httpserver.RequestIDHeader, tt.requestID)
+			}
+			if tt.traceparent != "" {
+				request.Header.Set(httpserver.TraceparentHeader, tt.traceparent)
+			}
+			engine.ServeHTTP(recorder, request)
+
+			requestID := recorder.Header().Get(httpserver.RequestIDHeader)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, requestID)
+			} else {
+				assert.NotEmpty(t, requestID)
+				assert.NotEqual(t, tt.requestID, requestID)
+			}
+
+			decoder := json.NewDecoder(&buf)
+			for _, message := range []string{"handling request", "GET /jobs"} {
+				var got map[string]any
+				require.NoError(t, decoder.Decode(&got))
+				assert.Equal(t, message, got["message"])
+				assert.Equal(t, requestID, got[logger.RequestIDKey])
+
+				if tt.wantTraceID != "" {
+					assert.Equal(t, tt.wantTraceID, got[logger.TraceIDKey])
+					assert.Equal(t, tt.wantSpanID, got[logger.SpanIDKey])
+				} else {
+					assert.NotContains(t, got, logger.TraceIDKey)
+				}
+			}
+		})
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		recovered any
+		wantChain []string
+	}{
+		{"error", io.ErrUnexpectedEOF, []string{httpserver.ErrPanicRecovered.Error(), io.ErrUnexpectedEOF.Error()}},
+		{"value", 42, []string{httpserver.ErrPanicRecovered.Error(), "42"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(logger.WithFormat(logger.JSONFormat), logger.WithStackTrace(true))
+			log.SetOutput(&buf)
+
+			engine := gin.New()
+			engine.Use(httpserver.NewGinLogger(log), httpserver.NewGinRecovery(log))
+			engine.GET("/jobs", func(*gin.Context) {
+				panic(tt.recovered)
+			})
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/jobs", http.NoBody))
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+			var got struct {
+				Message   string                `json:"message"`
+				RequestID string                `json:"requestID"`
+				Stack     []logger.StackFrame   `json:"error.stack"`
+				Chain     []logger.ChainedError `json:"error.chain"`
+			}
+			require.NoError(t, json.NewDecoder(&buf).Decode(&got))
+			assert.Contains(t, got.Message, httpserver.ErrPanicRecovered.Error())
+			assert.Equal(t, recorder.Header().Get(httpserver.RequestIDHeader), got.RequestID)
+			assert.True(t, slices.ContainsFunc(got.Stack, func(frame logger.StackFrame) bool {
+				return strings.Contains(frame.Function, "TestRecovery")
+			}))
+
+			chain := make([]string, 0, len(got.Chain))
+			for _, chained := range got.Chain {
+				chain = append(chain, chained.Message)
+			}
+			assert.Subset(t, chain, tt.wantChain)
 		})
 	}
 }
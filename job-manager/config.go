@@ -26,7 +26,7 @@ type Config struct {
 	RedisUsername string `json:"redis_username" yaml:"redis_username" mapstructure:"redis_username"`
 
 	// RedisPassword is the password for authenticating with the Redis server (optional).
-	RedisPassword string `json:"redis_password" yaml:"redis_password" mapstructure:"redis_password"`
+	RedisPassword config.Secret `json:"redis_password" yaml:"redis_password" mapstructure:"redis_password"`
 
 	// RedisNamespace is the namespace used to prefix keys in Redis.
 	RedisNamespace string `json:"redis_namespace" yaml:"redis_namespace" mapstructure:"redis_namespace"`
@@ -1,8 +1,31 @@
 package job_manager
 
+import (
+	"context"
+)
+
 // Job represents a task or work item that provides additional
 // functionality related to job management and status tracking.
 type Job interface {
 	// Start initiates the execution of the job.
 	Start() error
 }
+
+// ContextJob extends the Job interface with an optional context-aware start, which is called instead of Start.
+type ContextJob interface {
+	Job
+	// StartContext initiates the execution of the job. The context carries the job ID and the logger of the job
+	// manager, so log entries written with logger.FromContext can be tied to the job. It's canceled when the job
+	// manager stops.
+	StartContext(ctx context.Context) error
+}
+
+// StartJob is a helper function executed by a job manager to start a job.
+// It calls StartContext if the job implements the ContextJob interface, otherwise Start.
+func StartJob(ctx context.Context, job Job) error {
+	if job, ok := job.(ContextJob); ok {
+		return job.StartContext(ctx)
+	}
+
+	return job.Start()
+}
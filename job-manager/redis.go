@@ -143,7 +143,7 @@ func (r *RedisManager[T]) createClient() {
 	r.client = redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", r.cfg.RedisHost, r.cfg.RedisPort),
 		Username: r.cfg.RedisUsername,
-		Password: r.cfg.RedisPassword,
+		Password: string(r.cfg.RedisPassword.Reveal()),
 		DB:       0,
 		MaintNotificationsConfig: &maintnotifications.Config{
 			Mode: maintnotifications.ModeDisabled,
@@ -157,7 +157,7 @@ func (r *RedisManager[T]) monitorClientConnection() {
 	for {
 		if _, err := r.client.Ping(r.ctx).Result(); err != nil {
 			r.setReady(false)
-			r.log.Warnf("job-manager failed to ping redis: %v", err)
+			r.log.With("error", err).Warn("job-manager failed to ping redis")
 		} else {
 			r.setReady(true)
 			r.log.Debug("job-manager successfully pinged redis")
@@ -194,10 +194,10 @@ func (r *RedisManager[T]) setReady(ready bool) {
 
 // SetJob stores a job to the Redis store.
 func (r *RedisManager[T]) SetJob(jobID string, entity any) (err error) {
-	r.log.Debugf("job-manager sets job '%s': %+v", jobID, entity)
+	r.log.With(logger.JobIDKey, jobID, "job", entity).Debug("job-manager sets job")
 	_, err = r.client.JSONSet(r.ctx, r.cfg.RedisNamespace+":"+jobID, "$", entity).Result()
 	if err != nil {
-		r.log.Warnf("job-manager failed to set job '%s': %v", jobID, err)
+		r.log.With(logger.JobIDKey, jobID, "error", err).Warn("job-manager failed to set job")
 	}
 	return
 }
@@ -212,7 +212,7 @@ func (r *RedisManager[T]) GetJob(jobID string, entity Job) (err error) {
 
 	jobString, err := r.client.JSONGet(r.ctx, r.cfg.RedisNamespace+":"+jobID).Result()
 	if err != nil {
-		r.log.Warnf("job-manager failed to get job '%s': %v", jobID, err)
+		r.log.With(logger.JobIDKey, jobID, "error", err).Warn("job-manager failed to get job")
 		return
 	}
 	err = json.Unmarshal([]byte(jobString), entity)
@@ -268,7 +268,7 @@ func (r *RedisManager[T]) SetJobProgress(jobID, state string, progress uint64) {
 		},
 	}).Result()
 	if err != nil {
-		r.log.Warnf("job-manager failed to set job '%s' progress: %v", jobID, err)
+		r.log.With(logger.JobIDKey, jobID, "state", state, "error", err).Warn("job-manager failed to set job progress")
 	}
 }
 
@@ -309,10 +309,10 @@ func (r *RedisManager[T]) AddJobAndWait(entity Job) (err error) {
 	message := subscription.Channel()
 	select {
 	case <-message:
-		r.log.Debugf("job '%s' was completed", jobID)
+		r.log.With(logger.JobIDKey, jobID).Debug("job was completed")
 		return r.GetJob(jobID.String(), entity)
 	case <-time.After(r.cfg.Timeout):
-		r.log.Infof("job '%s' was timed out", jobID)
+		r.log.With(logger.JobIDKey, jobID).Info("job was timed out")
 		return ErrTimeoutExceeded
 	case <-r.ctx.Done():
 		return ErrJobManagerTerminated
@@ -335,13 +335,13 @@ func (r *RedisManager[T]) addJob(jobID string, entity Job) (err error) {
 	} {
 		_, err = r.client.Expire(r.ctx, key, r.cfg.RedisTTL).Result()
 		if err != nil {
-			r.log.Warnf("job-manager failed to add ttl to key '%s': %v", jobID, err)
+			r.log.With(logger.JobIDKey, jobID, "key", key, "error", err).Warn("job-manager failed to add ttl to key")
 		}
 	}
 
 	_, err = r.client.LPush(r.ctx, r.pendingJobsQueue, jobID).Result()
 	if err != nil {
-		r.log.Warnf("job-manager failed to add job '%s' to queue: %v", jobID, err)
+		r.log.With(logger.JobIDKey, jobID, "queue", r.pendingJobsQueue, "error", err).Warn("job-manager failed to add job to queue")
 	}
 	r.SetJobProgress(jobID, StatePending, 0)
 	return
@@ -354,15 +354,16 @@ func (r *RedisManager[T]) sendJobCompletionMessage(jobID string) {
 	var err error
 	subscribers, err = r.client.Publish(r.ctx, r.cfg.RedisNamespace+":"+jobID+":"+RedisChannelJobCompleted, "1").Result()
 	if err != nil {
-		r.log.Warnf("job-manager failed to send completion message to job '%s': %v", jobID, err)
+		r.log.With(logger.JobIDKey, jobID, "error", err).Warn("job-manager failed to send completion message")
 	} else {
-		r.log.Debugf("job-manager sends completion message of job '%s' with %d subscribers", jobID, subscribers)
+		r.log.With(logger.JobIDKey, jobID, "subscribers", subscribers).Debug("job-manager sends completion message")
 	}
 
 	if r.hasCompletionHooks {
 		_, err = r.client.LPush(r.ctx, r.completedJobsQueue, jobID).Result()
 		if err != nil {
-			r.log.Warnf("job-manager failed to add completion message to job '%s' to completed queue: %v", jobID, err)
+			r.log.With(logger.JobIDKey, jobID, "queue", r.completedJobsQueue, "error", err).
+				Warn("job-manager failed to add completion message to completed queue")
 		}
 	}
 }
@@ -370,7 +371,7 @@ func (r *RedisManager[T]) sendJobCompletionMessage(jobID string) {
 // drainProcessingJobsQueue processes all remaining jobs in the worker queue that were left over
 // from the previous shutdown or restart.
 func (r *RedisManager[T]) drainProcessingJobsQueue() {
-	r.log.Infof("job-manager drains processing queue '%s'", r.processingJobsQueue)
+	r.log.With("queue", r.processingJobsQueue).Info("job-manager drains processing queue")
 	for {
 		select {
 		case <-r.ctx.Done():
@@ -388,7 +389,7 @@ func (r *RedisManager[T]) drainProcessingJobsQueue() {
 // watchPendingJobsQueue monitors the pending jobs queue and transfers new jobs to the processing jobs queue
 // to ensure they are processed even after a system shutdown or restart.
 func (r *RedisManager[T]) watchPendingJobsQueue() {
-	r.log.Infof("job-manager watches pending jobs queue '%s'", r.pendingJobsQueue)
+	r.log.With("queue", r.pendingJobsQueue).Info("job-manager watches pending jobs queue")
 	for {
 		select {
 		case <-r.ctx.Done():
@@ -399,7 +400,7 @@ func (r *RedisManager[T]) watchPendingJobsQueue() {
 				continue
 			}
 			if err != nil {
-				r.log.Warnf("job-manager failed to watch pending queue '%s': %v", r.pendingJobsQueue, err)
+				r.log.With("queue", r.pendingJobsQueue, "error", err).Warn("job-manager failed to watch pending queue")
 				continue
 			}
 			if jobID != "" {
@@ -411,7 +412,7 @@ func (r *RedisManager[T]) watchPendingJobsQueue() {
 
 // watchCompletedJobsQueue initiate post-processing tasks of completed jobs.
 func (r *RedisManager[T]) watchCompletedJobsQueue() {
-	r.log.Infof("job-manager watches completed queue '%s'", r.completedJobsQueue)
+	r.log.With("queue", r.completedJobsQueue).Info("job-manager watches completed queue")
 	for {
 		select {
 		case <-r.ctx.Done():
@@ -422,7 +423,7 @@ func (r *RedisManager[T]) watchCompletedJobsQueue() {
 				continue
 			}
 			if err != nil {
-				r.log.Warnf("job-manager failed to watch completed queue '%s': %v", r.completedJobsQueue, err)
+				r.log.With("queue", r.completedJobsQueue, "error", err).Warn("job-manager failed to watch completed queue")
 				continue
 			}
 			//nolint:mnd // Redis returns nil or an array of size 2.
@@ -431,7 +432,7 @@ func (r *RedisManager[T]) watchCompletedJobsQueue() {
 				entityRef := any(&entity).(Job)
 				err = r.GetJob(response[1], entityRef)
 				if err != nil {
-					r.log.Warnf("job-manager failed to initiate post-processing tasks for job '%s': %v", response[1], err)
+					r.log.With(logger.JobIDKey, response[1], "error", err).Warn("job-manager failed to initiate post-processing tasks")
 					continue
 				}
 				ProcessCompletionHook(r.hookContext, entityRef)
@@ -444,13 +445,15 @@ func (r *RedisManager[T]) watchCompletedJobsQueue() {
 func (r *RedisManager[T]) processJob(jobID string) {
 	var entity T
 	entityRef := any(&entity).(Job)
-	r.log.Infof("job-manager processes job '%s'", jobID)
+	ctx := logger.ContextWithID(logger.NewContext(r.ctx, r.log), logger.JobIDKey, jobID)
+	log := logger.FromContext(ctx)
+	log.Info("job-manager processes job")
 
 	defer func() {
 		r.sendJobCompletionMessage(jobID)
 		_, err := r.client.LRem(r.ctx, r.processingJobsQueue, 1, jobID).Result()
 		if err != nil {
-			r.log.Warnf("job-manager failed to remove job '%s' from processing queue '%s': %v", jobID, r.processingJobsQueue, err)
+			log.With("queue", r.processingJobsQueue, "error", err).Warn("job-manager failed to remove job from processing queue")
 		}
 	}()
 
@@ -461,9 +464,9 @@ func (r *RedisManager[T]) processJob(jobID string) {
 		return
 	}
 
-	err = entityRef.Start()
+	err = StartJob(ctx, entityRef)
 	if err != nil {
-		r.log.Warnf("job-manager failed to start job '%s': %v", jobID, err)
+		log.With("error", err).Warn("job-manager failed to start job")
 		r.SetJobProgress(jobID, StateFailed, 0)
 		return
 	}
@@ -472,6 +475,6 @@ func (r *RedisManager[T]) processJob(jobID string) {
 		r.SetJobProgress(jobID, StateFailed, 0)
 		return
 	}
-	r.log.Infof("job-manager completed job '%s'", jobID)
+	log.Info("job-manager completed job")
 	r.SetJobProgress(jobID, StateCompleted, 0)
 }
@@ -0,0 +1,302 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultFlushTimeout limits the time Fatal waits for buffered entries to be written before terminating.
+const DefaultFlushTimeout = 5 * time.Second
+
+// OverflowPolicy determines what happens to an entry written to a full AsyncWriter.
+type OverflowPolicy int
+
+const (
+	// DropOldestPolicy drops the oldest buffered entry to make room for the new one (default).
+	DropOldestPolicy OverflowPolicy = 0 + iota
+
+	// DropNewestPolicy drops the new entry.
+	DropNewestPolicy
+
+	// BlockPolicy blocks the logging call until there is room for the new entry.
+	BlockPolicy
+)
+
+var (
+	ErrInvalidOverflowPolicy = errors.New("log overflow policy is invalid")
+
+	// OverflowPolicyToString is a map that converts an OverflowPolicy to its string representation.
+	//nolint:gochecknoglobals // This is a lookup map that needs to be globally accessible.
+	OverflowPolicyToString = map[OverflowPolicy]string{
+		DropOldestPolicy: "drop-oldest",
+		DropNewestPolicy: "drop-newest",
+		BlockPolicy:      "block",
+	}
+
+	// StringToOverflowPolicy is a map that converts a string to its OverflowPolicy equivalent.
+	//nolint:gochecknoglobals // This is a lookup map that needs to be globally accessible.
+	StringToOverflowPolicy = map[string]OverflowPolicy{
+		"drop-oldest": DropOldestPolicy,
+		"drop-newest": DropNewestPolicy,
+		"block":       BlockPolicy,
+	}
+)
+
+// MarshalText serializes the OverflowPolicy to a textual representation.
+func (r *OverflowPolicy) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// String returns the string representation of the OverflowPolicy.
+func (r *OverflowPolicy) String() string {
+	return OverflowPolicyToString[*r]
+}
+
+// UnmarshalText converts a textual representation of the overflow policy into an OverflowPolicy type.
+func (r *OverflowPolicy) UnmarshalText(text []byte) (err error) {
+	*r, err = ParseOverflowPolicy(string(text))
+
+	return
+}
+
+// ParseOverflowPolicy converts a string to its corresponding OverflowPolicy type.
+// Returns an error if the overflow policy is invalid.
+func ParseOverflowPolicy(policy string) (OverflowPolicy, error) {
+	if v, ok := StringToOverflowPolicy[policy]; ok {
+		return v, nil
+	}
+
+	return DropOldestPolicy, ErrInvalidOverflowPolicy
+}
+
+// AsyncWriter is an io.WriteCloser that buffers writes in a bounded ring buffer and writes them to the underlying
+// writer in the background, so logging calls don't wait for slow outputs. If the buffer is full, the
+// OverflowPolicy decides whether an entry is dropped or the call blocks.
+type AsyncWriter struct {
+	writer io.Writer
+	policy OverflowPolicy
+
+	mutex    sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     *sync.Cond
+	buffer   [][]byte
+	head     int
+	count    int
+	writing  bool
+	closed   bool
+	dropped  atomic.Uint64
+	stopped  chan struct{}
+}
+
+// NewAsyncWriter creates an AsyncWriter with a buffer for the given number of entries and starts writing to the
+// writer in the background.
+func NewAsyncWriter(writer io.Writer, size int, policy OverflowPolicy) *AsyncWriter {
+	async := &AsyncWriter{
+		writer:  writer,
+		policy:  policy,
+		buffer:  make([][]byte, max(size, 1)),
+		stopped: make(chan struct{}),
+	}
+	async.notEmpty = sync.NewCond(&async.mutex)
+	async.notFull = sync.NewCond(&async.mutex)
+	async.idle = sync.NewCond(&async.mutex)
+
+	go async.run()
+
+	return async
+}
+
+// Close writes the buffered entries and stops the background writing. The underlying writer is not closed.
+func (r *AsyncWriter) Close() error {
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return nil
+	}
+	r.closed = true
+	r.notEmpty.Broadcast()
+	r.notFull.Broadcast()
+	r.mutex.Unlock()
+
+	<-r.stopped
+
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the buffer was full.
+func (r *AsyncWriter) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Flush waits until the buffered entries are written and flushes the underlying writer, if it's a Flusher.
+// It returns the error of the context if it's done before.
+func (r *AsyncWriter) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+
+	go func() {
+		r.mutex.Lock()
+		for (r.count > 0 || r.writing) && !r.isStopped() {
+			r.idle.Wait()
+		}
+		r.mutex.Unlock()
+		close(flushed)
+	}()
+
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to flush log entries: %w", ctx.Err())
+	}
+
+	return flushWriter(ctx, r.Writer())
+}
+
+// SetWriter changes the underlying writer. Buffered entries are written to the new writer.
+func (r *AsyncWriter) SetWriter(writer io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.writer = writer
+}
+
+// Write copies the data into the buffer. It only blocks if the buffer is full and the policy is BlockPolicy.
+func (r *AsyncWriter) Write(data []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for r.count == len(r.buffer) && !r.closed {
+		switch r.policy {
+		case DropNewestPolicy:
+			r.dropped.Add(1)
+			return len(data), nil
+		case BlockPolicy:
+			r.notFull.Wait()
+		default:
+			r.buffer[r.head] = nil
+			r.head = (r.head + 1) % len(r.buffer)
+			r.count--
+			r.dropped.Add(1)
+		}
+	}
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	r.buffer[(r.head+r.count)%len(r.buffer)] = bytes.Clone(data)
+	r.count++
+	r.notEmpty.Signal()
+
+	return len(data), nil
+}
+
+// Writer returns the underlying writer.
+func (r *AsyncWriter) Writer() io.Writer {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.writer
+}
+
+// isStopped reports whether the background writing has stopped.
+func (r *AsyncWriter) isStopped() bool {
+	select {
+	case <-r.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// run writes the buffered entries until the writer is closed and the buffer is empty.
+// Errors of the underlying writer are ignored, since there is no caller to report them to.
+func (r *AsyncWriter) run() {
+	defer func() {
+		r.mutex.Lock()
+		close(r.stopped)
+		r.idle.Broadcast()
+		r.mutex.Unlock()
+	}()
+
+	r.mutex.Lock()
+	for {
+		for r.count == 0 && !r.closed {
+			r.notEmpty.Wait()
+		}
+
+		if r.count == 0 {
+			r.mutex.Unlock()
+			return
+		}
+
+		data, writer := r.buffer[r.head], r.writer
+		r.buffer[r.head] = nil
+		r.head = (r.head + 1) % len(r.buffer)
+		r.count--
+		r.writing = true
+		r.notFull.Signal()
+		r.mutex.Unlock()
+
+		_, _ = writer.Write(data)
+
+		r.mutex.Lock()
+		r.writing = false
+		if r.count == 0 {
+			r.idle.Broadcast()
+		}
+	}
+}
+
+// flushBeforeExit flushes the logger, but gives up after DefaultFlushTimeout, so a stuck output destination
+// cannot prevent the termination.
+func flushBeforeExit(flusher Flusher) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultFlushTimeout)
+	defer cancel()
+
+	_ = flusher.Flush(ctx)
+}
+
+// flushWriter flushes the writer if it's a Flusher.
+func flushWriter(ctx context.Context, writer io.Writer) error {
+	if flusher, ok := writer.(Flusher); ok {
+		return flusher.Flush(ctx) //nolint:wrapcheck // The writers of this package wrap their errors.
+	}
+
+	return nil
+}
+
+// setAsync enables, changes or, if the buffer size is not positive, disables the async mode of the log.Logger.
+// The buffered entries of the previous AsyncWriter are written before. It returns the new AsyncWriter, if any.
+func setAsync(logger *log.Logger, async *AsyncWriter, bufferSize int, policy OverflowPolicy) (*AsyncWriter, error) {
+	if _, ok := OverflowPolicyToString[policy]; !ok {
+		return async, ErrInvalidOverflowPolicy
+	}
+
+	previous, writer := async, logger.Writer()
+	if previous != nil {
+		writer = previous.Writer()
+	}
+
+	async = nil
+	if bufferSize > 0 {
+		async = NewAsyncWriter(writer, bufferSize, policy)
+		logger.SetOutput(async)
+	} else {
+		logger.SetOutput(writer)
+	}
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+
+	return async, nil
+}
@@ -0,0 +1,233 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedWriter blocks each write until it's released, so the buffer of an AsyncWriter fills up.
+type gatedWriter struct {
+	mutex   sync.Mutex
+	buffer  bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (r *gatedWriter) String() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.buffer.String()
+}
+
+func (r *gatedWriter) Write(data []byte) (int, error) {
+	select {
+	case r.started <- struct{}{}:
+	default:
+	}
+	<-r.release
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.buffer.Write(data)
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  string
+		want    logger.OverflowPolicy
+		wantErr error
+	}{
+		{name: "drop oldest", policy: "drop-oldest", want: logger.DropOldestPolicy},
+		{name: "drop newest", policy: "drop-newest", want: logger.DropNewestPolicy},
+		{name: "block", policy: "block", want: logger.BlockPolicy},
+		{name: "invalid", policy: "drop-all", want: logger.DropOldestPolicy, wantErr: logger.ErrInvalidOverflowPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := logger.ParseOverflowPolicy(tt.policy)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr == nil {
+				text, err := got.MarshalText()
+				require.NoError(t, err)
+				assert.Equal(t, tt.policy, string(text))
+			}
+		})
+	}
+}
+
+func TestAsyncWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      logger.OverflowPolicy
+		wantOutput  string
+		wantDropped uint64
+	}{
+		{name: "drop oldest", policy: logger.DropOldestPolicy, wantOutput: "1,4,5,", wantDropped: 2},
+		{name: "drop newest", policy: logger.DropNewestPolicy, wantOutput: "1,2,3,", wantDropped: 2},
+		{name: "block", policy: logger.BlockPolicy, wantOutput: "1,2,3,4,5,", wantDropped: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := newGatedWriter()
+			async := logger.NewAsyncWriter(writer, 2, tt.policy)
+
+			// The first entry is taken from the buffer and blocks in the writer, then the buffer is filled.
+			_, err := async.Write([]byte("1,"))
+			require.NoError(t, err)
+			<-writer.started
+
+			written := make(chan struct{})
+			go func() {
+				defer close(written)
+				for _, data := range []string{"2,", "3,", "4,", "5,"} {
+					_, _ = async.Write([]byte(data))
+				}
+			}()
+
+			if tt.policy == logger.BlockPolicy {
+				select {
+				case <-written:
+					t.Fatal("writes returned although the buffer is full")
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+
+			close(writer.release)
+			<-written
+
+			ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+			defer cancel()
+
+			require.NoError(t, async.Flush(ctx))
+			require.NoError(t, async.Close())
+			assert.Equal(t, tt.wantOutput, writer.String())
+			assert.Equal(t, tt.wantDropped, async.Dropped())
+		})
+	}
+}
+
+func TestAsyncWriter_Flush(t *testing.T) {
+	t.Parallel()
+
+	writer := newGatedWriter()
+	async := logger.NewAsyncWriter(writer, 8, logger.DropOldestPolicy)
+
+	_, err := async.Write([]byte("stuck"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, async.Flush(ctx), context.DeadlineExceeded)
+
+	close(writer.release)
+	require.NoError(t, async.Flush(t.Context()))
+	assert.Equal(t, "stuck", writer.String())
+
+	require.NoError(t, async.Close())
+	_, err = async.Write([]byte("closed"))
+	require.Error(t, err)
+}
+
+func TestDefaultLogger_SetAsync(t *testing.T) {
+	t.Parallel()
+
+	writer := newGatedWriter()
+	close(writer.release)
+
+	log := logger.New(logger.WithAsync(4, logger.BlockPolicy))
+	log.SetOutput(writer)
+
+	for range 10 {
+		log.Info("async message")
+	}
+
+	require.NoError(t, log.Flush(t.Context()))
+	assert.Equal(t, 10, strings.Count(writer.String(), "async message"))
+	assert.Zero(t, log.Dropped())
+
+	require.ErrorIs(t, log.SetAsync(4, logger.OverflowPolicy(42)), logger.ErrInvalidOverflowPolicy)
+	require.NoError(t, log.SetAsync(0, logger.DropOldestPolicy))
+	log.Info("sync message")
+	assert.Contains(t, writer.String(), "sync message")
+	require.NoError(t, log.Close())
+}
+
+//nolint:paralleltest // This test mocks OsExit.
+func TestDefaultLogger_FatalFlushes(t *testing.T) {
+	previous := logger.OsExit
+	t.Cleanup(func() {
+		logger.OsExit = previous
+	})
+
+	var exitOutput string
+
+	writer := newGatedWriter()
+	logger.OsExit = func(int) {
+		exitOutput = writer.String()
+	}
+
+	log := logger.New(logger.WithAsync(4, logger.DropNewestPolicy))
+	log.SetOutput(writer)
+
+	go func() {
+		<-writer.started
+		time.Sleep(20 * time.Millisecond)
+		close(writer.release)
+	}()
+
+	log.Fatal("fatal message") //nolint:revive // OsExit is mocked.
+
+	assert.Contains(t, exitOutput, "fatal message")
+}
+
+func TestMultiLogger_Flush(t *testing.T) {
+	t.Parallel()
+
+	first, second := newGatedWriter(), newGatedWriter()
+	close(first.release)
+	close(second.release)
+
+	log := logger.NewMultiLogger([]*logger.Sink{
+		logger.NewSink(first, logger.PlainFormat, logger.InfoLevel),
+		logger.NewSink(second, logger.JSONFormat, logger.InfoLevel),
+	}, logger.WithAsync(8, logger.DropOldestPolicy))
+
+	log.Info("message")
+
+	require.NoError(t, log.Flush(t.Context()))
+	assert.Contains(t, first.String(), "message")
+	assert.Contains(t, second.String(), `"message":"message"`)
+	assert.Zero(t, log.Dropped())
+	require.NoError(t, log.Close())
+}
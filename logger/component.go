@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// ComponentKey is the key of the field holding the name of a component logger, see Named.
+	ComponentKey = "component"
+
+	// AllComponents is the name of the level override that applies to all loggers without a more specific one.
+	AllComponents = "*"
+)
+
+// ComponentLevel describes the level of a named component logger.
+type ComponentLevel struct {
+	// Name is the name of the component, see Named.
+	Name string `json:"name"`
+
+	// Level is the effective level, i.e. the override, if any, or the configured level.
+	Level Level `json:"level"`
+
+	// Configured is the level set by the configuration or SetLevel, if a logger of the component exists.
+	Configured *Level `json:"configured,omitempty"`
+
+	// Override is the level overridden at runtime, if any.
+	Override *Level `json:"override,omitempty"`
+}
+
+// components keeps track of the configured levels of the component loggers and the overrides at runtime.
+//
+//nolint:gochecknoglobals // The overrides must apply to all loggers created by Named.
+var components = &componentRegistry{
+	configured: make(map[string]Level),
+}
+
+// ComponentLevels returns the levels of all component loggers and overrides, sorted by name.
+func ComponentLevels() []ComponentLevel {
+	return components.levels()
+}
+
+// ResetLevelOverride removes the runtime override of the component, so its configured level applies again.
+func ResetLevelOverride(name string) {
+	components.setOverride(name, nil)
+}
+
+// SetLevelOverride overrides the level of the component at runtime, regardless of the level of the logger.
+// The override of AllComponents applies to all loggers, including unnamed ones, without a more specific override.
+// Components don't need to exist yet, so levels can be overridden before a logger is created.
+func SetLevelOverride(name string, level Level) error {
+	if level < DebugLevel || level > FatalLevel {
+		return ErrInvalidLevel
+	}
+
+	components.setOverride(name, &level)
+
+	return nil
+}
+
+// componentRegistry holds the configured levels of the component loggers and the overrides at runtime.
+// The overrides are replaced as a whole, so they can be read on each log call without locking.
+type componentRegistry struct {
+	mutex      sync.Mutex
+	configured map[string]Level
+	overrides  atomic.Pointer[map[string]Level]
+}
+
+// effectiveLevel returns the override of the component, the override of AllComponents or the configured level.
+func (r *componentRegistry) effectiveLevel(name string, configured Level) Level {
+	overrides := r.overrides.Load()
+	if overrides == nil {
+		return configured
+	}
+
+	if level, ok := (*overrides)[name]; ok && name != "" {
+		return level
+	}
+
+	if level, ok := (*overrides)[AllComponents]; ok {
+		return level
+	}
+
+	return configured
+}
+
+// levels returns the levels of all registered and overridden components, sorted by name.
+func (r *componentRegistry) levels() []ComponentLevel {
+	r.mutex.Lock()
+	configured := maps.Clone(r.configured)
+	r.mutex.Unlock()
+
+	var overrides map[string]Level
+	if current := r.overrides.Load(); current != nil {
+		overrides = *current
+	}
+
+	names := slices.Collect(maps.Keys(configured))
+	for name := range overrides {
+		if _, ok := configured[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	levels := make([]ComponentLevel, 0, len(names))
+	for _, name := range names {
+		level := ComponentLevel{Name: name}
+
+		if configuredLevel, ok := configured[name]; ok {
+			level.Configured = &configuredLevel
+			level.Level = r.effectiveLevel(name, configuredLevel)
+		}
+
+		if override, ok := overrides[name]; ok {
+			level.Override = &override
+			level.Level = override
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
+// register records the configured level of the component.
+func (r *componentRegistry) register(name string, level Level) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.configured[name] = level
+}
+
+// setOverride sets or, if the level is nil, removes the override of the component.
+func (r *componentRegistry) setOverride(name string, level *Level) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	overrides := make(map[string]Level)
+	if current := r.overrides.Load(); current != nil {
+		overrides = maps.Clone(*current)
+	}
+
+	if level == nil {
+		delete(overrides, name)
+	} else {
+		overrides[name] = *level
+	}
+
+	r.overrides.Store(&overrides)
+}
+
+// componentName returns the name of a component nested in the parent component, e.g. "job-manager.redis".
+func componentName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
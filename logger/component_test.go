@@ -0,0 +1,146 @@
+package logger_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultLogger_Named(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithLevel(logger.InfoLevel))
+	log.SetOutput(&buf)
+
+	named := log.Named("test-default").With("jobID", 42)
+	named.Debug("hidden message")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, logger.SetLevelOverride("test-default", logger.DebugLevel))
+	t.Cleanup(func() {
+		logger.ResetLevelOverride("test-default")
+	})
+
+	named.Debug("debug message")
+	assert.Contains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), "component=test-default")
+	assert.Contains(t, buf.String(), "jobID=42")
+
+	// The override doesn't apply to the parent logger.
+	buf.Reset()
+	log.Debug("parent message")
+	assert.Empty(t, buf.String())
+
+	logger.ResetLevelOverride("test-default")
+	named.Debug("hidden message")
+	assert.Empty(t, buf.String())
+}
+
+func TestDefaultLogger_NamedNested(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New()
+	log.SetOutput(&buf)
+
+	log.Named("test-parent").(*logger.DefaultLogger).Named("child").Info("message")
+	assert.Contains(t, buf.String(), "component=test-parent.child")
+}
+
+func TestMultiLogger_Named(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+	log := logger.NewMultiLogger([]*logger.Sink{
+		logger.NewSink(&first, logger.PlainFormat, logger.InfoLevel),
+		logger.NewSink(&second, logger.PlainFormat, logger.ErrorLevel),
+	})
+
+	named := log.Named("test-multi")
+
+	require.NoError(t, logger.SetLevelOverride("test-multi", logger.WarnLevel))
+	t.Cleanup(func() {
+		logger.ResetLevelOverride("test-multi")
+	})
+
+	named.Info("info message")
+	named.Warn("warn message")
+	assert.NotContains(t, first.String(), "info message")
+	assert.Contains(t, first.String(), "warn message")
+	assert.Contains(t, second.String(), "warn message")
+}
+
+//nolint:paralleltest // The override of all components affects other tests.
+func TestSlogLogger_LevelOverride(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	log.Debug("hidden message")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, logger.SetLevelOverride(logger.AllComponents, logger.DebugLevel))
+	t.Cleanup(func() {
+		logger.ResetLevelOverride(logger.AllComponents)
+	})
+
+	log.Debug("debug message")
+	assert.Equal(t, logger.DebugLevel, log.Level())
+	assert.Contains(t, buf.String(), "debug message")
+
+	logger.ResetLevelOverride(logger.AllComponents)
+	assert.Equal(t, logger.InfoLevel, log.Level())
+}
+
+func TestSetLevelOverride(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New(logger.WithLevel(logger.WarnLevel))
+	log.Named("test-levels")
+
+	require.ErrorIs(t, logger.SetLevelOverride("test-levels", logger.Level(42)), logger.ErrInvalidLevel)
+	require.NoError(t, logger.SetLevelOverride("test-levels", logger.DebugLevel))
+	require.NoError(t, logger.SetLevelOverride("test-levels-missing", logger.ErrorLevel))
+	t.Cleanup(func() {
+		logger.ResetLevelOverride("test-levels")
+		logger.ResetLevelOverride("test-levels-missing")
+	})
+
+	levels := make(map[string]logger.ComponentLevel)
+	for _, level := range logger.ComponentLevels() {
+		levels[level.Name] = level
+	}
+
+	require.Contains(t, levels, "test-levels")
+	assert.Equal(t, logger.DebugLevel, levels["test-levels"].Level)
+	require.NotNil(t, levels["test-levels"].Configured)
+	assert.Equal(t, logger.WarnLevel, *levels["test-levels"].Configured)
+	require.NotNil(t, levels["test-levels"].Override)
+	assert.Equal(t, logger.DebugLevel, *levels["test-levels"].Override)
+
+	require.Contains(t, levels, "test-levels-missing")
+	assert.Equal(t, logger.ErrorLevel, levels["test-levels-missing"].Level)
+	assert.Nil(t, levels["test-levels-missing"].Configured)
+}
+
+//nolint:paralleltest // The override of all components affects other tests.
+func TestSetLevelOverride_AllComponents(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.New(logger.WithLevel(logger.ErrorLevel))
+	log.SetOutput(&buf)
+
+	require.NoError(t, logger.SetLevelOverride(logger.AllComponents, logger.DebugLevel))
+	log.Debug("debug message")
+	assert.Equal(t, logger.DebugLevel, log.Level())
+
+	logger.ResetLevelOverride(logger.AllComponents)
+	log.Info("info message")
+	assert.Equal(t, logger.ErrorLevel, log.Level())
+
+	assert.Contains(t, buf.String(), "debug message")
+	assert.NotContains(t, buf.String(), "info message")
+}
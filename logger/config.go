@@ -1,16 +1,93 @@
 package logger
 
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+)
+
 type Config struct {
-	Level  Level  `json:"level"  mapstructure:"level"  yaml:"level"`
-	Format Format `json:"format" mapstructure:"format" yaml:"format"`
-	Output string `json:"output" mapstructure:"output" yaml:"output"`
+	Level    Level          `json:"level"    mapstructure:"level"    yaml:"level"`
+	Format   Format         `json:"format"   mapstructure:"format"   yaml:"format"`
+	Output   string         `json:"output"   mapstructure:"output"   yaml:"output"`
+	Rotation RotationConfig `json:"rotation" mapstructure:"rotation" yaml:"rotation"`
+	Async    AsyncConfig    `json:"async"    mapstructure:"async"    yaml:"async"`
+	Redact   RedactConfig   `json:"redact"   mapstructure:"redact"   yaml:"redact"`
+
+	// StackTrace enables capturing the stack trace and the error chain of ErrorLevel and FatalLevel entries.
+	StackTrace bool `json:"stackTrace" mapstructure:"stackTrace" yaml:"stackTrace"`
+
+	// Sinks configures the outputs of a MultiLogger, each with its own format and level. If it's not empty,
+	// Level, Format, Output and Rotation are ignored by a MultiLogger. Loggers without sinks report an error and use
+	// Output instead, so NewFromConfig should be used to create the logger.
+	Sinks []SinkConfig `json:"sinks" mapstructure:"sinks" yaml:"sinks"`
+}
+
+// SinkConfig configures an output destination of a MultiLogger.
+type SinkConfig struct {
+	Level    Level          `json:"level"    mapstructure:"level"    yaml:"level"`
+	Format   Format         `json:"format"   mapstructure:"format"   yaml:"format"`
+	Output   string         `json:"output"   mapstructure:"output"   yaml:"output"`
+	Rotation RotationConfig `json:"rotation" mapstructure:"rotation" yaml:"rotation"`
+	Async    AsyncConfig    `json:"async"    mapstructure:"async"    yaml:"async"`
+}
+
+// AsyncConfig controls the async mode, which writes the entries in the background. Its zero value disables it.
+type AsyncConfig struct {
+	// BufferSize is the number of entries buffered in memory. Zero disables the async mode.
+	BufferSize int `json:"bufferSize" mapstructure:"bufferSize" yaml:"bufferSize"`
+
+	// Policy decides whether an entry is dropped or the call blocks if the buffer is full.
+	Policy OverflowPolicy `json:"policy" mapstructure:"policy" yaml:"policy"`
+}
+
+// RedactConfig controls the masking of secrets and personal data in log entries, see Redactor. Values of struct
+// fields tagged with `logger:"redact"` are masked, too.
+type RedactConfig struct {
+	// Fields are case-insensitive glob patterns of field names whose values are masked, e.g. "*password*".
+	Fields []string `json:"fields" mapstructure:"fields" yaml:"fields"`
+
+	// Patterns are regular expressions whose matches are masked in messages and string field values. If a pattern
+	// has capture groups, only the captured text is masked, e.g. the token of `Authorization: Bearer (\S+)`.
+	Patterns []string `json:"patterns" mapstructure:"patterns" yaml:"patterns"`
+}
+
+// RotationConfig controls the rotation and retention of file outputs. Its zero value disables the rotation.
+// It doesn't apply to the standard streams and remote syslog servers.
+type RotationConfig struct {
+	// MaxSize is the size in megabytes at which the file is rotated. Zero disables the rotation by size.
+	MaxSize int `json:"maxSize" mapstructure:"maxSize" yaml:"maxSize"`
+
+	// Interval is the time after which the file is rotated. Zero disables the rotation by time.
+	Interval time.Duration `json:"interval" mapstructure:"interval" yaml:"interval"`
+
+	// MaxBackups is the number of rotated files to keep. Zero keeps all of them.
+	MaxBackups int `json:"maxBackups" mapstructure:"maxBackups" yaml:"maxBackups"`
+
+	// MaxAge is the time after which rotated files are removed. Zero keeps them forever.
+	MaxAge time.Duration `json:"maxAge" mapstructure:"maxAge" yaml:"maxAge"`
+
+	// Compress enables the gzip compression of rotated files.
+	Compress bool `json:"compress" mapstructure:"compress" yaml:"compress"`
+
+	// ReopenOnHangup reopens the file on SIGHUP, so it can be rotated by external tools like logrotate.
+	ReopenOnHangup bool `json:"reopenOnHangup" mapstructure:"reopenOnHangup" yaml:"reopenOnHangup"`
 }
 
 // SetDefaults initializes the default values for the relevant fields in the struct.
 func (r *Config) SetDefaults() {
 	r.Level = InfoLevel
 	r.Format = PlainFormat
-	r.Output = "/dev/stderr"
+	r.Output = StderrOutput
+	r.Rotation = RotationConfig{}
+	r.Async = AsyncConfig{}
+	r.Redact = RedactConfig{
+		Fields:   DefaultRedactFields(),
+		Patterns: DefaultRedactPatterns(),
+	}
+	r.StackTrace = false
+	r.Sinks = nil
 }
 
 // Validate ensures the all necessary configurations are filled and within valid confines.
@@ -19,7 +96,42 @@ func (r *Config) Validate() error {
 		return ErrInvalidLevel
 	}
 
-	if r.Format < PlainFormat || r.Format > SyslogFormat {
+	if _, ok := lookupFormatter(r.Format); !ok {
+		return ErrInvalidFormat
+	}
+
+	if r.Output == "" {
+		return ErrInvalidOutput
+	}
+
+	if err := r.Rotation.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.Async.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.Redact.Validate(); err != nil {
+		return err
+	}
+
+	for i := range r.Sinks {
+		if err := r.Sinks[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate ensures the all necessary configurations are filled and within valid confines.
+func (r *SinkConfig) Validate() error {
+	if r.Level < DebugLevel || r.Level > FatalLevel {
+		return ErrInvalidLevel
+	}
+
+	if _, ok := lookupFormatter(r.Format); !ok {
 		return ErrInvalidFormat
 	}
 
@@ -27,5 +139,58 @@ func (r *Config) Validate() error {
 		return ErrInvalidOutput
 	}
 
+	if err := r.Rotation.Validate(); err != nil {
+		return err
+	}
+
+	return r.Async.Validate()
+}
+
+// IsEnabled reports whether the entries are written in the background.
+func (r *AsyncConfig) IsEnabled() bool {
+	return r.BufferSize > 0
+}
+
+// Validate ensures that the buffer size is not negative and the policy is known.
+func (r *AsyncConfig) Validate() error {
+	if r.BufferSize < 0 {
+		return ErrInvalidAsync
+	}
+
+	if _, ok := OverflowPolicyToString[r.Policy]; !ok {
+		return ErrInvalidOverflowPolicy
+	}
+
+	return nil
+}
+
+// Validate ensures that the field name patterns and the regular expressions are valid.
+func (r *RedactConfig) Validate() error {
+	for _, field := range r.Fields {
+		if _, err := path.Match(field, ""); err != nil {
+			return fmt.Errorf("%w: field pattern %q: %w", ErrInvalidRedact, field, err)
+		}
+	}
+
+	for _, pattern := range r.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRedact, err)
+		}
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether the file is rotated by size or interval.
+func (r *RotationConfig) IsEnabled() bool {
+	return r.MaxSize > 0 || r.Interval > 0
+}
+
+// Validate ensures that none of the limits is negative.
+func (r *RotationConfig) Validate() error {
+	if r.MaxSize < 0 || r.Interval < 0 || r.MaxBackups < 0 || r.MaxAge < 0 {
+		return ErrInvalidRotation
+	}
+
 	return nil
 }
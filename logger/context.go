@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"context"
+	"maps"
+)
+
+const (
+	// RequestIDKey is the key of the ID of an HTTP request, e.g. from the X-Request-ID header.
+	RequestIDKey = "requestID"
+
+	// TraceIDKey is the key of the ID of a distributed trace, e.g. from the W3C traceparent header.
+	TraceIDKey = "traceID"
+
+	// SpanIDKey is the key of the ID of a span within a distributed trace.
+	SpanIDKey = "spanID"
+
+	// PrincipalIDKey is the key of the ID of an authenticated principal.
+	PrincipalIDKey = "principalID"
+
+	// JobIDKey is the key of the ID of a job processed by a job manager.
+	JobIDKey = "jobID"
+)
+
+// ContextLogger extends Logger with methods that add the IDs stored in a context as fields, see ContextWithID.
+type ContextLogger interface {
+	Logger
+
+	DebugContext(ctx context.Context, v ...any)
+	InfoContext(ctx context.Context, v ...any)
+	WarnContext(ctx context.Context, v ...any)
+	ErrorContext(ctx context.Context, v ...any)
+}
+
+// contextKey is the type of the keys of this package in a context, which prevents collisions with other packages.
+type contextKey int
+
+const (
+	// loggerContextKey is the context key of the logger stored by NewContext.
+	loggerContextKey contextKey = iota
+
+	// idsContextKey is the context key of the IDs stored by ContextWithID.
+	idsContextKey
+)
+
+// NewContext returns a copy of the context that carries the logger, see FromContext.
+func NewContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, log)
+}
+
+// FromContext returns the logger stored in the context or the default logger, with the IDs stored in the context
+// added as fields.
+//
+//nolint:ireturn // The logger stored in the context could be any implementation.
+func FromContext(ctx context.Context) Logger {
+	log, ok := ctx.Value(loggerContextKey).(Logger)
+	if !ok {
+		log = std
+	}
+
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		return log.WithFields(fields)
+	}
+
+	return log
+}
+
+// ContextWithID returns a copy of the context that carries the ID under the key, e.g. RequestIDKey. The IDs are
+// added as fields by FromContext and the Context methods of the loggers, e.g. DefaultLogger.InfoContext.
+// Empty IDs are ignored.
+func ContextWithID(ctx context.Context, key, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	ids := make(Fields, 1)
+	if current, ok := ctx.Value(idsContextKey).(Fields); ok {
+		ids = maps.Clone(current)
+	}
+	ids[key] = id
+
+	return context.WithValue(ctx, idsContextKey, ids)
+}
+
+// ContextFields returns the IDs stored in the context as fields. The returned fields must not be modified.
+func ContextFields(ctx context.Context) Fields {
+	ids, _ := ctx.Value(idsContextKey).(Fields)
+
+	return ids
+}
+
+// IDFromContext returns the ID stored in the context under the key or an empty string.
+func IDFromContext(ctx context.Context, key string) string {
+	id, _ := ContextFields(ctx)[key].(string)
+
+	return id
+}
+
+// Package-level convenience functions that delegate to the logger of the context.
+
+func DebugContext(ctx context.Context, v ...any) {
+	FromContext(ctx).Debug(v...)
+}
+
+func InfoContext(ctx context.Context, v ...any) {
+	FromContext(ctx).Info(v...)
+}
+
+func WarnContext(ctx context.Context, v ...any) {
+	FromContext(ctx).Warn(v...)
+}
+
+func ErrorContext(ctx context.Context, v ...any) {
+	FromContext(ctx).Error(v...)
+}
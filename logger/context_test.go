@@ -0,0 +1,100 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithID(t *testing.T) {
+	t.Parallel()
+
+	ctx := logger.ContextWithID(t.Context(), logger.RequestIDKey, "request-1")
+	ctx = logger.ContextWithID(ctx, logger.TraceIDKey, "trace-1")
+	child := logger.ContextWithID(ctx, logger.RequestIDKey, "request-2")
+	ignored := logger.ContextWithID(ctx, logger.JobIDKey, "")
+
+	assert.Equal(t, logger.Fields{logger.RequestIDKey: "request-1", logger.TraceIDKey: "trace-1"}, logger.ContextFields(ctx))
+	assert.Equal(t, "request-2", logger.IDFromContext(child, logger.RequestIDKey))
+	assert.Equal(t, "request-1", logger.IDFromContext(ctx, logger.RequestIDKey))
+	assert.Empty(t, logger.IDFromContext(ignored, logger.JobIDKey))
+	assert.Empty(t, logger.ContextFields(t.Context()))
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.JSONFormat))
+	log.SetOutput(&buf)
+
+	ctx := logger.NewContext(t.Context(), log)
+	ctx = logger.ContextWithID(ctx, logger.JobIDKey, "42")
+	logger.FromContext(ctx).Info("processing")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "processing", got["message"])
+	assert.Equal(t, "42", got[logger.JobIDKey])
+
+	assert.Same(t, logger.Default(), logger.FromContext(context.Background()))
+}
+
+func TestDefaultLogger_InfoContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithLevel(logger.DebugLevel), logger.WithFormat(logger.JSONFormat))
+	log.SetOutput(&buf)
+
+	ctx := logger.ContextWithID(t.Context(), logger.RequestIDKey, "request-1")
+	ctx = logger.ContextWithID(ctx, logger.PrincipalIDKey, "alice")
+	log.InfoContext(ctx, "handling request")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "handling request", got["message"])
+	assert.Equal(t, "request-1", got[logger.RequestIDKey])
+	assert.Equal(t, "alice", got[logger.PrincipalIDKey])
+	assert.Equal(t, "context_test.go", got["file"])
+}
+
+func TestMultiLogger_WarnContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.NewMultiLogger([]*logger.Sink{logger.NewSink(&buf, logger.PlainFormat, logger.WarnLevel)})
+
+	ctx := logger.ContextWithID(t.Context(), logger.TraceIDKey, "trace-1")
+	log.InfoContext(ctx, "hidden message")
+	log.WarnContext(ctx, "warning")
+
+	assert.NotContains(t, buf.String(), "hidden message")
+	assert.Contains(t, buf.String(), "warning traceID=trace-1")
+}
+
+func TestSlogLogger_ErrorContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})
+	log := logger.NewSlogLogger(handler)
+
+	ctx := logger.ContextWithID(t.Context(), logger.SpanIDKey, "span-1")
+	log.ErrorContext(ctx, "failed")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "failed", got["msg"])
+	assert.Equal(t, "span-1", got[logger.SpanIDKey])
+
+	source, ok := got["source"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, source["file"], "context_test.go")
+}
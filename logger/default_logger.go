@@ -1,26 +1,36 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime"
 )
 
 // DefaultLogger is the default implementation of a logger with configurable format, level, and message output.
 type DefaultLogger struct {
-	appName string
-	logger  *log.Logger
-	format  Format
-	level   Level
-	output  func(*Entry, int)
+	appName    string
+	name       string
+	logger     *log.Logger
+	format     Format
+	formatter  Formatter
+	level      Level
+	fields     Fields
+	file       io.Closer
+	async      *AsyncWriter
+	redactor   *Redactor
+	stackTrace bool
+	shutdown   *fatalShutdown
 }
 
-// Ensure DefaultLogger implements the interface.
-var _ AdvancedLogger = (*DefaultLogger)(nil)
+// Ensure DefaultLogger implements the interfaces.
+var (
+	_ AdvancedLogger = (*DefaultLogger)(nil)
+	_ ContextLogger  = (*DefaultLogger)(nil)
+)
 
 // New creates a new default DefaultLogger instance.
 func New(opts ...Option) *DefaultLogger {
@@ -42,42 +52,83 @@ func New(opts ...Option) *DefaultLogger {
 	return logger
 }
 
+// Close writes the buffered entries of the async mode and closes the file opened by SetFileOutput, which are
+// shared with child loggers.
+func (r *DefaultLogger) Close() error {
+	r.async, _ = setAsync(r.logger, r.async, 0, DropOldestPolicy)
+
+	if r.file == nil {
+		return nil
+	}
+
+	file := r.file
+	r.file = nil
+
+	return file.Close() //nolint:wrapcheck // RotatingFile wraps its errors.
+}
+
 // Debug writes debug level messages.
 func (r *DefaultLogger) Debug(v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(DebugLevel, 2, nil, v...)
 }
 
+// DebugContext writes debug level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *DefaultLogger) DebugContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, DebugLevel, v)
+}
+
 // Debugf writes debug level messages using formatted string.
 func (r *DefaultLogger) Debugf(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(DebugLevel, 2, &format, v...)
 }
 
+// Dropped returns the number of entries dropped by the async mode because its buffer was full.
+func (r *DefaultLogger) Dropped() uint64 {
+	if r.async == nil {
+		return 0
+	}
+
+	return r.async.Dropped()
+}
+
 // Error writes error level messages.
 func (r *DefaultLogger) Error(v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(ErrorLevel, 2, nil, v...)
 }
 
+// ErrorContext writes error level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *DefaultLogger) ErrorContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, ErrorLevel, v)
+}
+
 // Errorf writes error level messages using formatted string.
 func (r *DefaultLogger) Errorf(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(ErrorLevel, 2, &format, v...)
 }
 
-// Fatal writes fatal level messages and terminates the application.
+// Fatal writes fatal level messages and terminates the application, gracefully if set by WithShutdown.
 func (r *DefaultLogger) Fatal(v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(FatalLevel, 2, nil, v...)
-	OsExit(1)
+	r.shutdown.exit(r)
 }
 
-// Fatalf writes fatal level messages using formatted string and terminates the application.
+// Fatalf writes fatal level messages using formatted string and terminates the application, gracefully if set by
+// WithShutdown.
 func (r *DefaultLogger) Fatalf(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(FatalLevel, 2, &format, v...)
-	OsExit(1)
+	r.shutdown.exit(r)
+}
+
+// Flush blocks until the entries buffered by the async mode or the output destination, e.g. a remote syslog
+// server, are written or the context is done.
+func (r *DefaultLogger) Flush(ctx context.Context) error {
+	return flushWriter(ctx, r.logger.Writer())
 }
 
 // Format returns the current output format setting of the logger.
@@ -91,6 +142,11 @@ func (r *DefaultLogger) Info(v ...any) {
 	r.Output(InfoLevel, 2, nil, v...)
 }
 
+// InfoContext writes info level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *DefaultLogger) InfoContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, InfoLevel, v)
+}
+
 // Infof writes info level messages using formatted string.
 func (r *DefaultLogger) Infof(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
@@ -99,88 +155,75 @@ func (r *DefaultLogger) Infof(format string, v ...any) {
 
 // Level returns the current logging level of the logger.
 func (r *DefaultLogger) Level() Level {
-	return r.level
+	return components.effectiveLevel(r.name, r.level)
+}
+
+// Named returns a child logger of the component, whose level can be overridden at runtime, see SetLevelOverride.
+// The name is added as field ComponentKey to each entry. Names of nested components are joined by dots.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *DefaultLogger) Named(name string) Logger {
+	name = componentName(r.name, name)
+	child := r.withFields(Fields{ComponentKey: name})
+	child.name = name
+	components.register(name, child.level)
+
+	return child
 }
 
 // Output is used to log messages with a specific level and format.
 func (r *DefaultLogger) Output(level Level, calldepth int, format *string, messages ...any) {
 	// If the current logger's level is higher than the given level, return immediately without logging anything.
-	if r.level > level {
+	minLevel := r.Level()
+	if minLevel > level {
 		return
 	}
 
 	var (
-		file  string
-		line  int
-		ok    bool
-		entry *Entry
+		file string
+		line int
 	)
 
-	if r.level == DebugLevel {
-		// Get information about the location of the logging call using runtime.Caller function with the provided calldepth.
-		// If it fails, set default values for filename and line number.
-		_, file, line, ok = runtime.Caller(calldepth)
-		if !ok {
-			file = "???"
-			line = 0
-		}
-
-		// Shorten the filename to only include the last part after the final '/'.
-		short := file
-		for i := len(file) - 1; i > 0; i-- {
-			if file[i] == '/' {
-				short = file[i+1:]
+	if minLevel == DebugLevel {
+		file, line = callerLocation(calldepth)
+	}
 
-				break
-			}
-		}
+	entry := NewEntry(level, file, line, newMessage(format, r.redactor.RedactArgs(messages)))
+	entry.Fields = r.fields
 
-		file = short
+	if r.stackTrace && level >= ErrorLevel {
+		entry.Error = newErrorDetails(calldepth, messages, entry.Fields)
 	}
 
-	// Create a new Entry with the given level, filename, line number, and message.
-	// If a format is nil, use fmt.Sprint to create the log message; otherwise, use fmt.Sprintf.
-	if format == nil {
-		if len(messages) == 1 {
-			entry = NewEntry(level, file, line, messages[0])
-		} else {
-			entry = NewEntry(level, file, line, fmt.Sprint(messages...))
-		}
-	} else {
-		entry = NewEntry(level, file, line, fmt.Sprintf(*format, messages...))
-	}
+	r.redactor.RedactEntry(entry)
 
-	// Pass the Entry to the output function for further processing and logging.
-	calldepth++
-	r.output(entry, calldepth)
+	_ = r.logger.Output(calldepth+1, r.formatter(r.appName, entry))
+}
+
+// SetAsync enables the async mode, which writes the entries in the background, so logging calls don't wait for
+// slow output destinations. The entries are buffered in a ring buffer of the given size; if it's full, the policy
+// decides whether an entry is dropped or the call blocks. A size of zero disables the async mode.
+func (r *DefaultLogger) SetAsync(bufferSize int, policy OverflowPolicy) (err error) {
+	r.async, err = setAsync(r.logger, r.async, bufferSize, policy)
+
+	return err
 }
 
 // SetFileOutput opens or creates the given file and set it as the new logging destination.
+// The paths "/dev/stdout" and "/dev/stderr" select the standard streams on all platforms.
 func (r *DefaultLogger) SetFileOutput(filename string) error {
-	// Using 0o600 permission to ensure only the owner can read and write the log file for security
-	//nolint:mnd // Permission flag is a valid constant
-	file, err := os.OpenFile(path.Clean(filename), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
-	if r.logger != nil {
-		r.logger.SetOutput(file)
-	}
-
-	return fmt.Errorf("failed to open log file: %w", err)
+	return r.SetRotatingFileOutput(filename, nil)
 }
 
-// SetFormat changes the output format of the logger to the specified format.
-func (r *DefaultLogger) SetFormat(format Format) (err error) {
-	switch format {
-	case PlainFormat:
-		r.output = r.outputPlain
-	case JSONFormat:
-		r.output = r.outputJSON
-	case SyslogFormat:
-		r.output = r.outputSyslog
-	default:
+// SetFormat changes the output format of the logger to the specified format, which may be a registered one.
+func (r *DefaultLogger) SetFormat(format Format) error {
+	formatter, ok := lookupFormatter(format)
+	if !ok {
 		return ErrInvalidFormat
 	}
 
 	r.format = format
+	r.formatter = formatter
 
 	return nil
 }
@@ -192,13 +235,59 @@ func (r *DefaultLogger) SetLevel(level Level) error {
 	}
 
 	r.level = level
+	if r.name != "" {
+		components.register(r.name, level)
+	}
 
 	return nil
 }
 
 // SetOutput sets the output destination of the logger to the specified writer.
 func (r *DefaultLogger) SetOutput(writer io.Writer) {
-	r.logger.SetOutput(writer)
+	r.setWriter(writer)
+}
+
+// SetRedactor sets the Redactor that masks secrets and personal data in all entries. Nil disables the redaction.
+func (r *DefaultLogger) SetRedactor(redactor *Redactor) {
+	r.redactor = redactor
+}
+
+// SetRotatingFileOutput opens or creates the given file, which is rotated according to the rotation, and set it
+// as the new logging destination. A previously opened file is closed. The rotation doesn't apply to the
+// standard streams.
+func (r *DefaultLogger) SetRotatingFileOutput(filename string, rotation *RotationConfig) error {
+	writer, file, err := openOutput(filename, rotation)
+	if err != nil {
+		return err
+	}
+
+	r.setWriter(writer)
+
+	previous := r.file
+	r.file = file
+
+	if previous != nil {
+		return previous.Close() //nolint:wrapcheck // RotatingFile wraps its errors.
+	}
+
+	return nil
+}
+
+// SetShutdown sets the Shutdowner that terminates the application with the exit code on Fatal, e.g. a
+// terminator.Terminator. Nil exits immediately with FatalExitCode.
+func (r *DefaultLogger) SetShutdown(shutdowner Shutdowner, exitCode int) {
+	if shutdowner == nil {
+		r.shutdown = nil
+		return
+	}
+
+	r.shutdown = &fatalShutdown{shutdowner: shutdowner, exitCode: exitCode}
+}
+
+// SetStackTrace enables or disables capturing the stack trace and the error chain of ErrorLevel and FatalLevel
+// entries, see ErrorDetails.
+func (r *DefaultLogger) SetStackTrace(enabled bool) {
+	r.stackTrace = enabled
 }
 
 // Warn writes warning level messages.
@@ -207,6 +296,11 @@ func (r *DefaultLogger) Warn(v ...any) {
 	r.Output(WarnLevel, 2, nil, v...)
 }
 
+// WarnContext writes warning level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *DefaultLogger) WarnContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, WarnLevel, v)
+}
+
 // Warnf writes warning level messages using formatted string.
 func (r *DefaultLogger) Warnf(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
@@ -224,3 +318,88 @@ func (r *DefaultLogger) Warningf(format string, v ...any) {
 	//nolint:mnd // Skips over this method in stack trace.
 	r.Output(WarnLevel, 2, &format, v...)
 }
+
+// With returns a child logger that adds the alternating keys and values to each entry, see NewFields.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *DefaultLogger) With(keyValues ...any) Logger {
+	return r.WithFields(NewFields(keyValues...))
+}
+
+// WithFields returns a child logger that adds the fields to each entry in addition to the fields of this logger.
+// The child starts with the level and format of this logger and shares its output destination.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *DefaultLogger) WithFields(fields Fields) Logger {
+	return r.withFields(fields)
+}
+
+// outputContext writes the messages with the IDs stored in the context as fields.
+func (r *DefaultLogger) outputContext(ctx context.Context, level Level, messages []any) {
+	if r.Level() > level {
+		return
+	}
+
+	log := r
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		log = r.withFields(fields)
+	}
+
+	//nolint:mnd // Skips over this method and the calling Context method in stack trace.
+	log.Output(level, 3, nil, messages...)
+}
+
+// setWriter changes the output destination. In async mode, the buffered entries are written to the previous one
+// before.
+func (r *DefaultLogger) setWriter(writer io.Writer) {
+	if r.async == nil {
+		r.logger.SetOutput(writer)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultFlushTimeout)
+	defer cancel()
+
+	_ = r.async.Flush(ctx)
+	r.async.SetWriter(writer)
+}
+
+// withFields returns a child logger with the fields, see WithFields.
+func (r *DefaultLogger) withFields(fields Fields) *DefaultLogger {
+	child := *r
+	child.fields = r.fields.Merge(fields)
+
+	return &child
+}
+
+// callerLocation returns the short file name and line number of the caller at the calldepth, where 0 is the
+// caller of callerLocation. If it fails, "???" and 0 are returned.
+func callerLocation(calldepth int) (string, int) {
+	// Get information about the location of the logging call using runtime.Caller function with the provided calldepth.
+	_, file, line, ok := runtime.Caller(calldepth + 1)
+	if !ok {
+		return "???", 0
+	}
+
+	// Shorten the filename to only include the last part after the final '/'.
+	for i := len(file) - 1; i > 0; i-- {
+		if file[i] == '/' {
+			return file[i+1:], line
+		}
+	}
+
+	return file, line
+}
+
+// newMessage creates the message of an Entry. If a format is nil, a single message is used as is to keep its
+// JSON representation and multiple messages are joined using fmt.Sprint; otherwise, fmt.Sprintf is used.
+func newMessage(format *string, messages []any) any {
+	switch {
+	case format != nil:
+		return fmt.Sprintf(*format, messages...)
+	case len(messages) == 1:
+		return messages[0]
+	default:
+		return fmt.Sprint(messages...)
+	}
+}
@@ -23,6 +23,13 @@ type Entry struct {
 
 	// Line contains the corresponding line number where the log was created.
 	Line int `json:"line,omitempty"`
+
+	// Fields contains the key/value pairs bound to the logger. They are written as top-level keys in JSON.
+	Fields Fields `json:"-"`
+
+	// Error contains the stack trace and the error chain of ErrorLevel and FatalLevel entries, if enabled by
+	// WithStackTrace. They are written as the keys "error.stack" and "error.chain" in JSON.
+	Error *ErrorDetails `json:"-"`
 }
 
 // NewEntry creates a new Entry with the given parameters and current time.
@@ -36,19 +43,27 @@ func NewEntry(level Level, file string, line int, message any) *Entry {
 	}
 }
 
-// Marshal converts an Entry to a JSON byte array and returns it.
-// If there's an error during conversion, it wraps the error.
+// Marshal converts an Entry to a JSON byte array and returns it. Errors as Message are marshaled as their message.
+// The Fields and the Error details are added as top-level keys. If there's an error during conversion, it wraps
+// the error.
 func (r *Entry) Marshal() ([]byte, error) {
 	if r.Message == nil {
 		r.Message = ""
 	}
 
-	out, err := json.Marshal(r)
+	entry := *r
+	entry.Message = fieldValue(r.Message)
+
+	out, err := json.Marshal(&entry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal Entry: %w", err)
 	}
 
-	return out, nil
+	if out, err = appendJSONFields(out, r.Fields); err != nil {
+		return nil, err
+	}
+
+	return appendJSONErrorDetails(out, r.Error)
 }
 
 // String returns the string representation of the Entry's Message.
@@ -1,6 +1,7 @@
 package logger_test
 
 import (
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -59,6 +60,21 @@ func TestItem_Marshal(t *testing.T) {
 			},
 			`{"date":"2024-02-05T09:15:30Z", "level":"debug", "message":{"text":"Test message", "number":123456}}`,
 		},
+		{
+			"fields",
+			&logger.Entry{
+				Date:    mockDate,
+				Message: "Test message",
+				Fields: logger.Fields{
+					"jobID": "42",
+					"count": 3,
+					"level": "shadowed",
+					"err":   errors.New("failed"),
+				},
+			},
+			`{"date":"2024-02-05T09:15:30Z", "level":"debug", "message":"Test message",` +
+				`"jobID":"42", "count":3, "fields.level":"shadowed", "err":"failed"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -4,4 +4,12 @@ import (
 	"errors"
 )
 
-var ErrInvalidOutput = errors.New("logger output is invalid")
+var (
+	ErrInvalidOutput   = errors.New("logger output is invalid")
+	ErrInvalidRotation = errors.New("logger rotation is invalid")
+	ErrInvalidAsync    = errors.New("logger async mode is invalid")
+	ErrInvalidRedact   = errors.New("logger redaction is invalid")
+
+	// ErrNotSupported is returned if a logger does not support an operation, e.g. changing the format of a SlogLogger.
+	ErrNotSupported = errors.New("operation is not supported by the logger")
+)
@@ -0,0 +1,345 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// BadKey is the key of a value passed to With without a key, e.g. the last of an odd number of arguments.
+	BadKey = "!BADKEY"
+
+	// ReservedFieldPrefix is prepended to field keys that collide with the keys of an Entry in JSON output,
+	// e.g. a field "level" is written as "fields.level".
+	ReservedFieldPrefix = "fields."
+
+	// SyslogSDID is the SD-ID of the RFC 5424 structured-data element that holds the caller and the fields.
+	SyslogSDID = "goSDID@32473"
+
+	// syslogParamNameSize is the maximum length of an SD-PARAM name according to RFC 5424.
+	syslogParamNameSize = 32
+)
+
+// Fields are key/value pairs that are bound to a logger and attached to each of its entries.
+type Fields map[string]any
+
+// NewFields creates Fields from alternating keys and values, e.g. NewFields("jobID", id, "queue", name).
+// Keys that are not strings are formatted with fmt.Sprint. A trailing value without a key is stored as BadKey.
+func NewFields(keyValues ...any) Fields {
+	fields := make(Fields, (len(keyValues)+1)/2) //nolint:mnd // Each field consists of a key and a value.
+
+	for i := 0; i < len(keyValues); i += 2 {
+		if i+1 == len(keyValues) {
+			fields[BadKey] = keyValues[i]
+			break
+		}
+
+		key, ok := keyValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyValues[i])
+		}
+		fields[key] = keyValues[i+1]
+	}
+
+	return fields
+}
+
+// Keys returns the keys of the fields in sorted order, so the output is deterministic.
+func (r Fields) Keys() []string {
+	return slices.Sorted(maps.Keys(r))
+}
+
+// Merge returns new Fields containing the fields and the given ones, which take precedence on duplicate keys.
+// Neither of them is modified.
+func (r Fields) Merge(fields Fields) Fields {
+	merged := make(Fields, len(r)+len(fields))
+	maps.Copy(merged, r)
+	maps.Copy(merged, fields)
+
+	return merged
+}
+
+// appendJSONFields inserts the fields as top-level keys into the marshaled JSON object of an Entry.
+// Keys that collide with the keys of the Entry are prefixed with ReservedFieldPrefix.
+func appendJSONFields(object []byte, fields Fields) ([]byte, error) {
+	if len(fields) == 0 {
+		return object, nil
+	}
+
+	var builder strings.Builder
+	builder.Write(object[:len(object)-1])
+
+	for _, key := range fields.Keys() {
+		value, err := json.Marshal(fieldValue(fields[key]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal field %q: %w", key, err)
+		}
+
+		name := key
+		if isEntryKey(key) {
+			name = ReservedFieldPrefix + key
+		}
+		encodedName, _ := json.Marshal(name)
+
+		builder.WriteByte(',')
+		builder.Write(encodedName)
+		builder.WriteByte(':')
+		builder.Write(value)
+	}
+
+	builder.WriteByte('}')
+
+	return []byte(builder.String()), nil
+}
+
+// appendJSONErrorDetails inserts the stack trace and the error chain as the top-level keys "error.stack" and
+// "error.chain" into the marshaled JSON object of an Entry. An empty chain is omitted.
+func appendJSONErrorDetails(object []byte, details *ErrorDetails) ([]byte, error) {
+	if details == nil {
+		return object, nil
+	}
+
+	var builder strings.Builder
+	builder.Write(object[:len(object)-1])
+
+	stack, err := json.Marshal(details.Stack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stack trace: %w", err)
+	}
+	builder.WriteString(`,"error.stack":`)
+	builder.Write(stack)
+
+	if len(details.Chain) > 0 {
+		chain, err := json.Marshal(details.Chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal error chain: %w", err)
+		}
+		builder.WriteString(`,"error.chain":`)
+		builder.Write(chain)
+	}
+
+	builder.WriteByte('}')
+
+	return []byte(builder.String()), nil
+}
+
+// writePlainFields appends the fields as space-separated `k=v` pairs. Values containing spaces, quotes, `=` or
+// control characters are quoted.
+func writePlainFields(builder *strings.Builder, fields Fields) {
+	for _, key := range fields.Keys() {
+		builder.WriteByte(' ')
+		builder.WriteString(quotePlainValue(key))
+		builder.WriteByte('=')
+		builder.WriteString(quotePlainValue(fmt.Sprint(fields[key])))
+	}
+}
+
+// writeLogfmtFields appends the fields as logfmt key/value pairs. Keys that collide with the keys written by
+// LogfmtFormat are prefixed with ReservedFieldPrefix.
+func writeLogfmtFields(builder *strings.Builder, fields Fields) {
+	for _, key := range fields.Keys() {
+		name := logfmtKey(key)
+		switch name {
+		case "ts", "level", "app", "caller", "msg":
+			name = ReservedFieldPrefix + name
+		}
+
+		builder.WriteByte(' ')
+		builder.WriteString(name)
+		builder.WriteByte('=')
+		builder.WriteString(quotePlainValue(fmt.Sprint(fieldValue(fields[key]))))
+	}
+}
+
+// writeGELFFields appends the fields as GELF additional fields, i.e. prefixed with an underscore. Numbers are
+// written as they are, other values as strings, since GELF doesn't support nested values.
+func writeGELFFields(builder *strings.Builder, fields Fields) {
+	for _, key := range fields.Keys() {
+		name := gelfFieldName(key)
+		if name == "id" || name == "app" || name == "file" || name == "line" {
+			// The additional field "_id" is not allowed and the others are written by GELFFormat.
+			name = ReservedFieldPrefix + name
+		}
+
+		value := fieldValue(fields[key])
+		if !isNumber(value) {
+			value = fmt.Sprint(value)
+		}
+
+		writeJSONMember(builder, "_"+name, value)
+	}
+}
+
+// writeECSFields appends the fields as top-level keys of an Elastic Common Schema JSON object. Errors stored as
+// "error" are written as error.message. Keys that collide with the keys written by ECSFormat are prefixed with
+// ReservedFieldPrefix.
+func writeECSFields(builder *strings.Builder, fields Fields) {
+	for _, key := range fields.Keys() {
+		value := fields[key]
+		name := key
+
+		switch {
+		case key == "error":
+			if err, ok := value.(error); ok {
+				name, value = "error.message", err.Error()
+			}
+		case key == "@timestamp" || key == "message" || strings.HasPrefix(key, "log.") ||
+			strings.HasPrefix(key, "ecs.") || key == "service.name" || key == "error.stack_trace" ||
+			key == "error.type":
+			name = ReservedFieldPrefix + key
+		}
+
+		writeJSONMember(builder, name, fieldValue(value))
+	}
+}
+
+// writeJSONMember appends the key and the value as member of the JSON object in the builder, separated by a comma
+// from the previous member. Values that cannot be marshaled are written as formatted by fmt.Sprint.
+func writeJSONMember(builder *strings.Builder, key string, value any) {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		encodedValue, _ = json.Marshal(fmt.Sprint(value))
+	}
+	encodedName, _ := json.Marshal(key)
+
+	if builder.Len() > 1 {
+		builder.WriteByte(',')
+	}
+	builder.Write(encodedName)
+	builder.WriteByte(':')
+	builder.Write(encodedValue)
+}
+
+// writeSyslogParams appends the fields as SD-PARAMs of an RFC 5424 structured-data element, e.g. ` jobID="42"`.
+func writeSyslogParams(builder *strings.Builder, fields Fields) {
+	for _, key := range fields.Keys() {
+		builder.WriteByte(' ')
+		builder.WriteString(syslogParamName(key))
+		builder.WriteString(`="`)
+		builder.WriteString(EscapeSyslogParamValue(fmt.Sprint(fields[key])))
+		builder.WriteByte('"')
+	}
+}
+
+// EscapeSyslogParamValue escapes the characters `"`, `\` and `]` of an SD-PARAM value as required by RFC 5424.
+func EscapeSyslogParamValue(value string) string {
+	replacer := strings.NewReplacer(
+		`"`, `\"`,
+		`\`, `\\`,
+		`]`, `\]`,
+	)
+
+	return replacer.Replace(value)
+}
+
+// syslogParamName converts a key to a valid SD-PARAM name, which consists of at most 32 printable US-ASCII
+// characters except `=`, space, `]` and `"`. Invalid characters are replaced by underscores.
+func syslogParamName(key string) string {
+	name := []byte(key)
+	for i, char := range name {
+		if char <= ' ' || char > '~' || char == '=' || char == ']' || char == '"' {
+			name[i] = '_'
+		}
+	}
+
+	if len(name) > syslogParamNameSize {
+		name = name[:syslogParamNameSize]
+	}
+	if len(name) == 0 {
+		return "_"
+	}
+
+	return string(name)
+}
+
+// logfmtKey converts a key to a valid logfmt key, which must not contain spaces, `=`, quotes or control characters.
+// Invalid characters are replaced by underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(char rune) rune {
+		if char == '=' || char == '"' || unicode.IsSpace(char) || !unicode.IsPrint(char) {
+			return '_'
+		}
+
+		return char
+	}, key)
+}
+
+// gelfFieldName converts a key to a valid name of a GELF additional field, which consists of letters, digits,
+// underscores, dots and dashes. Invalid characters are replaced by underscores.
+func gelfFieldName(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(char rune) rune {
+		if char > unicode.MaxASCII || (char != '.' && char != '-' && char != '_' &&
+			!unicode.IsLetter(char) && !unicode.IsDigit(char)) {
+			return '_'
+		}
+
+		return char
+	}, key)
+}
+
+// quotePlainValue quotes the text if it's empty or contains characters that would make `k=v` ambiguous.
+func quotePlainValue(text string) string {
+	if text == "" {
+		return `""`
+	}
+
+	if strings.ContainsFunc(text, func(char rune) bool {
+		return char == '=' || char == '"' || unicode.IsSpace(char) || !unicode.IsPrint(char)
+	}) {
+		return strconv.Quote(text)
+	}
+
+	return text
+}
+
+// fieldValue returns the value to marshal for a field. Errors are marshaled as their message, since most of
+// them have no exported fields.
+func fieldValue(value any) any {
+	if err, ok := value.(error); ok {
+		if _, ok = value.(json.Marshaler); !ok {
+			return err.Error()
+		}
+	}
+
+	return value
+}
+
+// isNumber reports whether the value is an integer or a floating-point number.
+func isNumber(value any) bool {
+	if _, ok := value.(json.Marshaler); ok {
+		return false
+	}
+
+	switch reflect.ValueOf(value).Kind() { //nolint:exhaustive // All other kinds are no numbers.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEntryKey reports whether the key is used by the JSON representation of an Entry.
+func isEntryKey(key string) bool {
+	switch key {
+	case "date", "file", "level", "message", "line", "error.stack", "error.chain":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		keyValues []any
+		want      logger.Fields
+	}{
+		{
+			name: "empty",
+			want: logger.Fields{},
+		},
+		{
+			name:      "pairs",
+			keyValues: []any{"jobID", "42", "attempt", 2},
+			want:      logger.Fields{"jobID": "42", "attempt": 2},
+		},
+		{
+			name:      "non-string key",
+			keyValues: []any{7, true},
+			want:      logger.Fields{"7": true},
+		},
+		{
+			name:      "missing value",
+			keyValues: []any{"jobID", "42", "dangling"},
+			want:      logger.Fields{"jobID": "42", logger.BadKey: "dangling"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, logger.NewFields(tt.keyValues...))
+		})
+	}
+}
+
+func TestDefaultLogger_With(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format logger.Format
+		log    func(log logger.Logger)
+		want   []string
+	}{
+		{
+			name:   "plain",
+			format: logger.PlainFormat,
+			log: func(log logger.Logger) {
+				log.With("jobID", "42", "path", "/a b").Info("processing")
+			},
+			want: []string{`processing jobID=42 path="/a b"`},
+		},
+		{
+			name:   "plain nested",
+			format: logger.PlainFormat,
+			log: func(log logger.Logger) {
+				log.With("jobID", "42").WithFields(logger.Fields{"jobID": "43", "queue": "pending"}).Info("moved")
+			},
+			want: []string{`moved jobID=43 queue=pending`},
+		},
+		{
+			name:   "syslog",
+			format: logger.SyslogFormat,
+			log: func(log logger.Logger) {
+				log.With("jobID", "42", "bad key", `a"]b`).Info("processing")
+			},
+			want: []string{`[goSDID@32473 bad_key="a\"\]b" jobID="42"] processing`},
+		},
+		{
+			name:   "syslog without fields",
+			format: logger.SyslogFormat,
+			log: func(log logger.Logger) {
+				log.Info("processing")
+			},
+			want: []string{` - - - processing`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(logger.WithFormat(tt.format))
+			log.SetOutput(&buf)
+
+			tt.log(log)
+
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestDefaultLogger_WithJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.JSONFormat))
+	log.SetOutput(&buf)
+
+	child := log.With("jobID", "42")
+	child.Info("processing")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "42", got["jobID"])
+	assert.Equal(t, "processing", got["message"])
+
+	// The parent logger is not affected by the fields of its child.
+	buf.Reset()
+	log.Info("done")
+	assert.NotContains(t, buf.String(), "jobID")
+}
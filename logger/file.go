@@ -0,0 +1,363 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// BackupTimeFormat is the timestamp inserted into the names of rotated files, e.g. "app-20240205T091530.000.log".
+	BackupTimeFormat = "20060102T150405.000"
+
+	// CompressSuffix is appended to the names of compressed rotated files.
+	CompressSuffix = ".gz"
+
+	// megabyte is the unit of RotationConfig.MaxSize.
+	megabyte = 1024 * 1024
+
+	// filePermissions ensures only the owner can read and write log files.
+	filePermissions = 0o600
+)
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and/or interval.
+// Rotated files are renamed with a timestamp, optionally compressed, and removed according to the retention.
+// It's safe for concurrent use.
+type RotatingFile struct {
+	filename string
+	rotation RotationConfig
+
+	mutex        sync.Mutex
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	closed       bool
+
+	millMutex sync.Mutex
+	millGroup sync.WaitGroup
+
+	hangup chan os.Signal
+	done   chan struct{}
+}
+
+// NewRotatingFile opens or creates the file in append mode. If the rotation is nil, the file is never rotated.
+func NewRotatingFile(filename string, rotation *RotationConfig) (*RotatingFile, error) {
+	file := &RotatingFile{
+		filename: filepath.Clean(filename),
+		done:     make(chan struct{}),
+	}
+	if rotation != nil {
+		file.rotation = *rotation
+	}
+
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+
+	if file.rotation.ReopenOnHangup {
+		file.hangup = make(chan os.Signal, 1)
+		signal.Notify(file.hangup, syscall.SIGHUP)
+		go file.watchHangup()
+	}
+
+	return file, nil
+}
+
+// Close stops watching for SIGHUP, waits for pending compressions and closes the file.
+func (r *RotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if r.hangup != nil {
+		signal.Stop(r.hangup)
+		close(r.done)
+	}
+
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.millGroup.Wait()
+
+	if err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	return nil
+}
+
+// Reopen closes and reopens the file, e.g. after it was moved by an external tool like logrotate.
+func (r *RotatingFile) Reopen() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+
+	return r.open()
+}
+
+// Rotate renames the current file with a timestamp and opens a new one.
+func (r *RotatingFile) Rotate() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+
+	return r.rotate()
+}
+
+// Write writes the data to the file. The file is rotated before, if the data would exceed the maximum size or
+// the rotation interval has elapsed.
+func (r *RotatingFile) Write(data []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	if r.file == nil {
+		// Reopening the file failed before, e.g. because its directory was removed temporarily.
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	maxSize := int64(r.rotation.MaxSize) * megabyte
+	exceedsSize := maxSize > 0 && r.size > 0 && r.size+int64(len(data)) > maxSize
+	intervalElapsed := !r.nextRotation.IsZero() && !time.Now().Before(r.nextRotation)
+
+	if exceedsSize || intervalElapsed {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	size, err := r.file.Write(data)
+	r.size += int64(size)
+
+	if err != nil {
+		return size, fmt.Errorf("failed to write log file: %w", err)
+	}
+
+	return size, nil
+}
+
+// backupName returns the name of a rotated file, which is the name of the file with the timestamp inserted
+// before the extension.
+func (r *RotatingFile) backupName(date time.Time) string {
+	ext := filepath.Ext(r.filename)
+
+	return strings.TrimSuffix(r.filename, ext) + "-" + date.Format(BackupTimeFormat) + ext
+}
+
+// backups returns the rotated files of the file, newest first.
+func (r *RotatingFile) backups() ([]rotatedFile, error) {
+	dir := filepath.Dir(r.filename)
+	ext := filepath.Ext(r.filename)
+	prefix := strings.TrimSuffix(filepath.Base(r.filename), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	var backups []rotatedFile
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), CompressSuffix)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		date, err := time.ParseInLocation(BackupTimeFormat, timestamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, rotatedFile{path: filepath.Join(dir, entry.Name()), date: date})
+	}
+
+	slices.SortFunc(backups, func(a, b rotatedFile) int {
+		return b.date.Compare(a.date)
+	})
+
+	return backups, nil
+}
+
+// mill compresses the rotated file, if enabled, and removes rotated files exceeding the retention.
+// Errors are ignored, since they cannot be logged to the file being rotated.
+func (r *RotatingFile) mill(backup string) {
+	r.millMutex.Lock()
+	defer r.millMutex.Unlock()
+
+	if r.rotation.Compress {
+		_ = compressFile(backup)
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-r.rotation.MaxAge)
+	for i, backup := range backups {
+		tooMany := r.rotation.MaxBackups > 0 && i >= r.rotation.MaxBackups
+		tooOld := r.rotation.MaxAge > 0 && backup.date.Before(cutoff)
+
+		if tooMany || tooOld {
+			_ = os.Remove(backup.path)
+		}
+	}
+}
+
+// open opens or creates the file in append mode and schedules the next rotation.
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+
+	if r.rotation.Interval > 0 {
+		r.nextRotation = time.Now().Add(r.rotation.Interval)
+	}
+
+	return nil
+}
+
+// rotate renames the current file, opens a new one and starts compressing and removing the rotated files.
+func (r *RotatingFile) rotate() error {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+		r.file = nil
+	}
+
+	backup := r.backupName(time.Now())
+	if err := os.Rename(r.filename, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.millGroup.Add(1)
+	go func() {
+		defer r.millGroup.Done()
+		r.mill(backup)
+	}()
+
+	return nil
+}
+
+// watchHangup reopens the file on SIGHUP until the file is closed.
+func (r *RotatingFile) watchHangup() {
+	for {
+		select {
+		case <-r.hangup:
+			_ = r.Reopen()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// openOutput returns the writer for the output destination and, if a file or connection was opened, its closer.
+// The paths StdoutOutput and StderrOutput select the standard streams, which are never rotated. Addresses like
+// "tcp+tls://host:6514" select a remote syslog server, see NewSyslogWriter.
+func openOutput(filename string, rotation *RotationConfig) (io.Writer, io.Closer, error) {
+	switch {
+	case filename == StdoutOutput:
+		return os.Stdout, nil, nil
+	case filename == StderrOutput:
+		return os.Stderr, nil, nil
+	case isSyslogAddress(filename):
+		writer, err := NewSyslogWriter(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return writer, writer, nil
+	default:
+		file, err := NewRotatingFile(filename, rotation)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return file, file, nil
+	}
+}
+
+// rotatedFile is a rotated file and the time of its rotation.
+type rotatedFile struct {
+	path string
+	date time.Time
+}
+
+// compressFile compresses the file with gzip and removes the uncompressed file.
+func compressFile(filename string) error {
+	source, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return fmt.Errorf("failed to open rotated log file: %w", err)
+	}
+	defer func() {
+		_ = source.Close()
+	}()
+
+	target, err := os.OpenFile(filename+CompressSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create compressed log file: %w", err)
+	}
+
+	writer := gzip.NewWriter(target)
+	_, err = io.Copy(writer, source)
+	if err == nil {
+		err = writer.Close()
+	}
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(filename + CompressSuffix)
+		return fmt.Errorf("failed to compress log file: %w", err)
+	}
+
+	_ = source.Close()
+
+	return os.Remove(filename) //nolint:wrapcheck // The error of os.Remove describes the file.
+}
@@ -0,0 +1,163 @@
+package logger_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rotation *logger.RotationConfig
+		writes   int
+		size     int
+		verify   func(t *testing.T, dir string, backups []string)
+	}{
+		{
+			name:   "without rotation",
+			writes: 3,
+			size:   1024 * 1024,
+			verify: func(t *testing.T, _ string, backups []string) {
+				t.Helper()
+
+				assert.Empty(t, backups)
+			},
+		},
+		{
+			name:     "rotation by size",
+			rotation: &logger.RotationConfig{MaxSize: 1},
+			writes:   3,
+			size:     1024 * 1024,
+			verify: func(t *testing.T, _ string, backups []string) {
+				t.Helper()
+
+				assert.Len(t, backups, 2)
+			},
+		},
+		{
+			name:     "retention by count",
+			rotation: &logger.RotationConfig{MaxSize: 1, MaxBackups: 1},
+			writes:   4,
+			size:     1024 * 1024,
+			verify: func(t *testing.T, _ string, backups []string) {
+				t.Helper()
+
+				assert.Len(t, backups, 1)
+			},
+		},
+		{
+			name:     "rotation by interval with compression",
+			rotation: &logger.RotationConfig{Interval: 10 * time.Millisecond, Compress: true},
+			writes:   2,
+			size:     16,
+			verify: func(t *testing.T, _ string, backups []string) {
+				t.Helper()
+
+				require.Len(t, backups, 1)
+				require.True(t, strings.HasSuffix(backups[0], logger.CompressSuffix))
+
+				compressed, err := os.Open(backups[0])
+				require.NoError(t, err)
+				defer compressed.Close()
+
+				reader, err := gzip.NewReader(compressed)
+				require.NoError(t, err)
+
+				content, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				assert.Equal(t, bytes.Repeat([]byte{'x'}, 16), content)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			file, err := logger.NewRotatingFile(filepath.Join(dir, "app.log"), tt.rotation)
+			require.NoError(t, err)
+
+			for range tt.writes {
+				_, err = file.Write(bytes.Repeat([]byte{'x'}, tt.size))
+				require.NoError(t, err)
+				time.Sleep(20 * time.Millisecond)
+			}
+			require.NoError(t, file.Close())
+
+			backups, err := filepath.Glob(filepath.Join(dir, "app-*.log*"))
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(dir, "app.log"))
+			tt.verify(t, dir, backups)
+		})
+	}
+}
+
+func TestRotatingFile_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	expired := filepath.Join(dir, "app-"+time.Now().Add(-2*time.Hour).Format(logger.BackupTimeFormat)+".log")
+	require.NoError(t, os.WriteFile(expired, []byte("old"), 0o600))
+
+	file, err := logger.NewRotatingFile(filepath.Join(dir, "app.log"), &logger.RotationConfig{MaxAge: time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, file.Rotate())
+	require.NoError(t, file.Close())
+
+	assert.NoFileExists(t, expired)
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestDefaultLogger_SetFileOutput(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "app.log")
+	log := logger.New()
+
+	require.NoError(t, log.SetFileOutput(filename))
+	log.Info("test message")
+	require.NoError(t, log.Close())
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "test message")
+
+	require.Error(t, log.SetFileOutput(filepath.Join(t.TempDir(), "missing", "app.log")))
+	require.NoError(t, log.SetFileOutput(logger.StderrOutput))
+}
+
+func TestWithConfig_Rotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &logger.Config{}
+	cfg.SetDefaults()
+	cfg.Output = filepath.Join(dir, "app.log")
+	cfg.Rotation.MaxSize = 1
+
+	log := logger.New(logger.WithConfig(cfg))
+	for range 3 {
+		log.Info(strings.Repeat("x", 600*1024))
+	}
+	require.NoError(t, log.Close())
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_ReopenOnHangup(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+
+	file, err := logger.NewRotatingFile(filename, &logger.RotationConfig{ReopenOnHangup: true})
+	require.NoError(t, err)
+	defer file.Close()
+
+	// Simulate an external logrotate moving the file away.
+	require.NoError(t, os.Rename(filename, filename+".1"))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filename)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = file.Write([]byte("reopened"))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "reopened", string(content))
+}
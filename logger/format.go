@@ -2,6 +2,7 @@ package logger
 
 import (
 	"errors"
+	"sync"
 )
 
 // Format is used to specify the format in which logs should be written.
@@ -16,26 +17,62 @@ const (
 
 	// SyslogFormat represents logs in syslog format.
 	SyslogFormat
+
+	// LogfmtFormat represents logs as logfmt key/value pairs, e.g. for Loki.
+	LogfmtFormat
+
+	// GELFFormat represents logs in the Graylog Extended Log Format 1.1, e.g. for Graylog.
+	GELFFormat
+
+	// ECSFormat represents logs in JSON following the Elastic Common Schema, e.g. for Elasticsearch.
+	ECSFormat
 )
 
+// Formatter converts an entry of the application to a single line of the log output.
+type Formatter func(appName string, entry *Entry) string
+
 var (
 	ErrInvalidFormat = errors.New("log format is invalid")
+	ErrFormatExists  = errors.New("log format already exists")
 
 	// FormatToString is a map that converts a Format to its string representation.
+	// It must not be modified directly, see RegisterFormat.
 	//nolint:gochecknoglobals // This is a lookup map that needs to be globally accessible.
 	FormatToString = map[Format]string{
 		PlainFormat:  "plain",
 		JSONFormat:   "json",
 		SyslogFormat: "syslog",
+		LogfmtFormat: "logfmt",
+		GELFFormat:   "gelf",
+		ECSFormat:    "ecs",
 	}
 
 	// StringToFormat is a map that converts a string to its Format equivalent.
+	// It must not be modified directly, see RegisterFormat.
 	//nolint:gochecknoglobals // This is a lookup map that needs to be globally accessible.
 	StringToFormat = map[string]Format{
 		"plain":  PlainFormat,
 		"json":   JSONFormat,
 		"syslog": SyslogFormat,
+		"logfmt": LogfmtFormat,
+		"gelf":   GELFFormat,
+		"ecs":    ECSFormat,
+	}
+
+	// formatters is the registry of the functions converting entries to the formats.
+	//nolint:gochecknoglobals // The registry must be shared by all loggers.
+	formatters = map[Format]Formatter{
+		PlainFormat:  func(_ string, entry *Entry) string { return formatPlain(entry) },
+		JSONFormat:   func(_ string, entry *Entry) string { return formatJSON(entry) },
+		SyslogFormat: formatSyslog,
+		LogfmtFormat: formatLogfmt,
+		GELFFormat:   formatGELF,
+		ECSFormat:    formatECS,
 	}
+
+	// formatsMutex guards the registry and the lookup maps against concurrent registrations.
+	//nolint:gochecknoglobals // The mutex guards the global registry.
+	formatsMutex sync.RWMutex
 )
 
 // MarshalText serializes the Format to a textual representation.
@@ -45,6 +82,9 @@ func (r *Format) MarshalText() ([]byte, error) {
 
 // String returns the string representation of the Format.
 func (r *Format) String() string {
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+
 	return FormatToString[*r]
 }
 
@@ -55,12 +95,52 @@ func (r *Format) UnmarshalText(text []byte) (err error) {
 	return
 }
 
-// ParseFormat converts a string to its corresponding Format type.
+// ParseFormat converts a string to its corresponding Format type, including the registered ones.
 // Returns an error if the format is invalid.
 func ParseFormat(format string) (Format, error) {
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+
 	if v, ok := StringToFormat[format]; ok {
 		return v, nil
 	}
 
 	return PlainFormat, ErrInvalidFormat
 }
+
+// RegisterFormat adds a format with the given name, so it can be selected by ParseFormat, the configuration and
+// SetFormat of all loggers. It returns the new Format or ErrFormatExists if the name is already taken.
+// Formats should be registered during initialization, e.g. in an init function, before the lookup maps are read.
+func RegisterFormat(name string, formatter Formatter) (Format, error) {
+	if name == "" || formatter == nil {
+		return PlainFormat, ErrInvalidFormat
+	}
+
+	formatsMutex.Lock()
+	defer formatsMutex.Unlock()
+
+	if _, ok := StringToFormat[name]; ok {
+		return PlainFormat, ErrFormatExists
+	}
+
+	format := Format(len(formatters))
+	for formatters[format] != nil {
+		format++
+	}
+
+	formatters[format] = formatter
+	FormatToString[format] = name
+	StringToFormat[name] = format
+
+	return format, nil
+}
+
+// lookupFormatter returns the function converting entries to the format, if the format is registered.
+func lookupFormatter(format Format) (Formatter, bool) {
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+
+	formatter, ok := formatters[format]
+
+	return formatter, ok
+}
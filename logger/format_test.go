@@ -0,0 +1,145 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    logger.Format
+		wantErr error
+	}{
+		{"plain", logger.PlainFormat, nil},
+		{"json", logger.JSONFormat, nil},
+		{"syslog", logger.SyslogFormat, nil},
+		{"logfmt", logger.LogfmtFormat, nil},
+		{"gelf", logger.GELFFormat, nil},
+		{"ecs", logger.ECSFormat, nil},
+		{"xml", logger.PlainFormat, logger.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := logger.ParseFormat(tt.name)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := logger.RegisterFormat("test-upper", func(appName string, entry *logger.Entry) string {
+		return strings.ToUpper(appName + ": " + entry.String())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "test-upper", format.String())
+
+	_, err = logger.RegisterFormat("test-upper", func(string, *logger.Entry) string { return "" })
+	require.ErrorIs(t, err, logger.ErrFormatExists)
+	_, err = logger.RegisterFormat("json", func(string, *logger.Entry) string { return "" })
+	require.ErrorIs(t, err, logger.ErrFormatExists)
+	_, err = logger.RegisterFormat("test-nil", nil)
+	require.ErrorIs(t, err, logger.ErrInvalidFormat)
+
+	parsed, err := logger.ParseFormat("test-upper")
+	require.NoError(t, err)
+	assert.Equal(t, format, parsed)
+
+	cfg := logger.Config{}
+	cfg.SetDefaults()
+	cfg.Format = format
+	require.NoError(t, cfg.Validate())
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithConfig(&cfg))
+	log.SetOutput(&buf)
+	log.Info("message")
+	assert.Equal(t, strings.ToUpper(filepath.Base(os.Args[0]))+": MESSAGE\n", buf.String())
+}
+
+func TestLogfmtFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.LogfmtFormat), logger.WithLevel(logger.DebugLevel))
+	log.SetOutput(&buf)
+
+	log.With("jobID", 42, "msg", "collision", "bad key", `a "quoted" value`).Warn("payment failed")
+
+	line := buf.String()
+	assert.Regexp(t, `^ts=\S+ level=warn app=\S+ caller=format_test.go:\d+ msg="payment failed" `, line)
+	assert.Contains(t, line, `bad_key="a \"quoted\" value"`)
+	assert.Contains(t, line, "fields.msg=collision")
+	assert.Contains(t, line, "jobID=42")
+}
+
+func TestGELFFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.GELFFormat), logger.WithLevel(logger.DebugLevel))
+	log.SetOutput(&buf)
+
+	log.With("jobID", 42, "id", "collision", "app", "collision", "error", errors.New("declined"), "user name", "bob").
+		Error("payment failed")
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, logger.GELFVersion, got["version"])
+	assert.Equal(t, hostname, got["host"])
+	assert.Equal(t, filepath.Base(os.Args[0]), got["_app"])
+	assert.Equal(t, "collision", got["_fields.app"])
+	assert.Equal(t, "payment failed", got["short_message"])
+	assert.InDelta(t, 3, got["level"], 0)
+	assert.IsType(t, float64(0), got["timestamp"])
+	assert.Equal(t, "format_test.go", got["_file"])
+	assert.IsType(t, float64(0), got["_line"])
+	assert.InDelta(t, 42, got["_jobID"], 0)
+	assert.Equal(t, "collision", got["_fields.id"])
+	assert.Equal(t, "declined", got["_error"])
+	assert.Equal(t, "bob", got["_user_name"])
+	assert.NotContains(t, got, "_id")
+}
+
+func TestECSFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.ECSFormat))
+	log.SetOutput(&buf)
+
+	log.With("jobID", "42", "message", "collision", "error", errors.New("declined")).Error("payment failed")
+
+	assert.True(t, strings.HasPrefix(buf.String(), `{"@timestamp":`))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "error", got["log.level"])
+	assert.Equal(t, "payment failed", got["message"])
+	assert.Equal(t, logger.ECSVersion, got["ecs.version"])
+	assert.Equal(t, filepath.Base(os.Args[0]), got["service.name"])
+	assert.Equal(t, "42", got["jobID"])
+	assert.Equal(t, "collision", got["fields.message"])
+	assert.Equal(t, "declined", got["error.message"])
+	assert.NotContains(t, got, "log.origin.file.name")
+}
@@ -1,5 +1,14 @@
 package logger
 
+import (
+	"context"
+)
+
+// namedLogger is implemented by loggers that support component loggers, e.g. DefaultLogger.
+type namedLogger interface {
+	Named(name string) Logger
+}
+
 //nolint:gochecknoglobals // std is a global logger instance that needs to be accessible throughout the package.
 var std Logger = New()
 
@@ -56,3 +65,38 @@ func Fatal(v ...any) {
 func Fatalf(format string, v ...any) {
 	std.Fatalf(format, v...)
 }
+
+// Named returns a component logger of the default logger, whose level can be overridden at runtime, see
+// SetLevelOverride. If the default logger doesn't support it, only the field ComponentKey is added.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func Named(name string) Logger {
+	if named, ok := std.(namedLogger); ok {
+		return named.Named(name)
+	}
+
+	return std.With(ComponentKey, name)
+}
+
+// With returns a child logger of the default logger with the alternating keys and values as fields.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func With(keyValues ...any) Logger {
+	return std.With(keyValues...)
+}
+
+// WithFields returns a child logger of the default logger with the fields.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func WithFields(fields Fields) Logger {
+	return std.WithFields(fields)
+}
+
+// Flush writes the buffered entries of the default logger, if it's a Flusher.
+func Flush(ctx context.Context) error {
+	if flusher, ok := std.(Flusher); ok {
+		return flusher.Flush(ctx) //nolint:wrapcheck // The loggers of this package wrap their errors.
+	}
+
+	return nil
+}
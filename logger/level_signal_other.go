@@ -0,0 +1,9 @@
+//go:build !unix
+
+package logger
+
+// WatchLevelSignals does nothing, since SIGUSR1 and SIGUSR2 are not available on this platform.
+// The returned function does nothing either.
+func WatchLevelSignals() func() {
+	return func() {}
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// WatchLevelSignals overrides the level of all loggers with DebugLevel on SIGUSR1 and restores their configured
+// levels on SIGUSR2, see AllComponents. Overrides of single components are kept. The returned function stops
+// watching.
+func WatchLevelSignals() func() {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGUSR1 {
+					_ = SetLevelOverride(AllComponents, DebugLevel)
+				} else {
+					ResetLevelOverride(AllComponents)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+//go:build unix
+
+package logger_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // The override of all components affects other tests.
+func TestWatchLevelSignals(t *testing.T) {
+	stop := logger.WatchLevelSignals()
+	defer stop()
+
+	log := logger.New(logger.WithLevel(logger.WarnLevel))
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	assert.Eventually(t, func() bool {
+		return log.Level() == logger.DebugLevel
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	assert.Eventually(t, func() bool {
+		return log.Level() == logger.WarnLevel
+	}, time.Second, 10*time.Millisecond)
+}
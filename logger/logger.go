@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"io"
 )
 
@@ -16,6 +17,12 @@ type Logger interface {
 	Errorf(format string, v ...any)
 	Fatal(v ...any)
 	Fatalf(format string, v ...any)
+
+	// With returns a child logger that adds the alternating keys and values as fields to each entry.
+	With(keyValues ...any) Logger
+
+	// WithFields returns a child logger that adds the fields to each entry.
+	WithFields(fields Fields) Logger
 }
 
 // ConfigurableLogger extends Logger with configuration methods.
@@ -39,3 +46,17 @@ type AdvancedLogger interface {
 	Warningf(format string, v ...any)
 	Output(level Level, calldepth int, format *string, v ...any)
 }
+
+// Flusher is implemented by loggers and writers that buffer entries, e.g. in async mode.
+type Flusher interface {
+	// Flush blocks until the buffered entries are written or the context is done.
+	Flush(ctx context.Context) error
+}
+
+// Shutdowner gracefully shuts down the application, e.g. terminator.Terminator. Loggers call it on Fatal instead
+// of exiting immediately, if set by WithShutdown.
+type Shutdowner interface {
+	// Shutdown stops the running tasks, waits for them up to a timeout and terminates the application with the
+	// exit code.
+	Shutdown(exitCode int)
+}
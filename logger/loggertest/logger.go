@@ -0,0 +1,420 @@
+package loggertest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+)
+
+// Ensure Logger implements the interfaces.
+var (
+	_ logger.AdvancedLogger = (*Logger)(nil)
+	_ logger.ContextLogger  = (*Logger)(nil)
+)
+
+// Logger is a logger.AdvancedLogger that records the entries in memory instead of writing them, so tests can
+// assert what was logged. Child loggers created by With and WithFields share the recorded entries.
+type Logger struct {
+	t        testing.TB
+	recorder *recorder
+	level    logger.Level
+	format   logger.Format
+	fields   logger.Fields
+}
+
+// recorder holds the entries of a Logger and its children.
+type recorder struct {
+	mutex     sync.Mutex
+	entries   []logger.Entry
+	expected  []expectation
+	failLevel *logger.Level
+}
+
+// expectation is an entry of the level with the substring in its message, see Logger.Expect.
+type expectation struct {
+	level     logger.Level
+	substring string
+}
+
+// New creates a Logger that records entries of all levels, unless another level is set by an option, e.g.
+// logger.WithLevel.
+func New(t testing.TB, opts ...logger.Option) *Logger {
+	t.Helper()
+
+	log := &Logger{
+		t:        t,
+		recorder: &recorder{},
+		level:    logger.DebugLevel,
+		format:   logger.PlainFormat,
+	}
+
+	for _, opt := range opts {
+		opt(log)
+	}
+
+	t.Cleanup(log.failOnUnexpected)
+
+	return log
+}
+
+// WithFailOnUnexpected fails the test at its end if entries of at least the level were recorded that were
+// neither expected by Expect nor asserted by AssertLogged, e.g. logger.WarnLevel for unexpected warnings and errors.
+// Other loggers ignore the option.
+func WithFailOnUnexpected(level logger.Level) logger.Option {
+	return func(l logger.ConfigurableLogger) {
+		if log, ok := l.(*Logger); ok {
+			log.recorder.mutex.Lock()
+			defer log.recorder.mutex.Unlock()
+
+			log.recorder.failLevel = &level
+		}
+	}
+}
+
+// AssertLogged asserts that an entry of the level with the substring in its message was recorded and marks such
+// entries as expected, see WithFailOnUnexpected. It reports whether the assertion succeeded.
+func (r *Logger) AssertLogged(t testing.TB, level logger.Level, substring string) bool {
+	t.Helper()
+
+	r.Expect(level, substring)
+
+	if r.find(level, substring) {
+		return true
+	}
+
+	t.Errorf("loggertest: no %s entry containing %q was logged, entries:\n%s", level.String(), substring,
+		describeEntries(r.Entries()))
+
+	return false
+}
+
+// AssertNotLogged asserts that no entry of the level with the substring in its message was recorded. It reports
+// whether the assertion succeeded.
+func (r *Logger) AssertNotLogged(t testing.TB, level logger.Level, substring string) bool {
+	t.Helper()
+
+	if !r.find(level, substring) {
+		return true
+	}
+
+	t.Errorf("loggertest: unexpected %s entry containing %q was logged, entries:\n%s", level.String(), substring,
+		describeEntries(r.Entries(level)))
+
+	return false
+}
+
+// Debug writes debug level messages.
+func (r *Logger) Debug(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.DebugLevel, 2, nil, v...)
+}
+
+// DebugContext writes debug level messages with the IDs stored in the context as fields.
+func (r *Logger) DebugContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, logger.DebugLevel, v)
+}
+
+// Debugf writes debug level messages using formatted string.
+func (r *Logger) Debugf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.DebugLevel, 2, &format, v...)
+}
+
+// Entries returns copies of the recorded entries of the given levels, or of all levels if none are given, in the
+// order they were logged.
+func (r *Logger) Entries(levels ...logger.Level) []logger.Entry {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+
+	entries := make([]logger.Entry, 0, len(r.recorder.entries))
+	for _, entry := range r.recorder.entries {
+		if len(levels) == 0 || slices.Contains(levels, entry.Level) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// Error writes error level messages.
+func (r *Logger) Error(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.ErrorLevel, 2, nil, v...)
+}
+
+// ErrorContext writes error level messages with the IDs stored in the context as fields.
+func (r *Logger) ErrorContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, logger.ErrorLevel, v)
+}
+
+// Errorf writes error level messages using formatted string.
+func (r *Logger) Errorf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.ErrorLevel, 2, &format, v...)
+}
+
+// Expect marks entries of the level with the substring in their message as expected, so they don't fail the test,
+// see WithFailOnUnexpected. It can be called before or after the entries are logged.
+func (r *Logger) Expect(level logger.Level, substring string) {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+
+	r.recorder.expected = append(r.recorder.expected, expectation{level: level, substring: substring})
+}
+
+// Fatal writes fatal level messages and stops the calling goroutine by runtime.Goexit instead of terminating the
+// application, so code after the call doesn't run. Call the code under test in its own goroutine to continue the
+// test after Fatal.
+func (r *Logger) Fatal(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.FatalLevel, 2, nil, v...)
+	runtime.Goexit()
+}
+
+// Fatalf writes fatal level messages using formatted string and stops the calling goroutine, see Fatal.
+func (r *Logger) Fatalf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.FatalLevel, 2, &format, v...)
+	runtime.Goexit()
+}
+
+// Format returns the format set by SetFormat, which doesn't affect the recorded entries.
+func (r *Logger) Format() logger.Format {
+	return r.format
+}
+
+// Info writes info level messages.
+func (r *Logger) Info(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.InfoLevel, 2, nil, v...)
+}
+
+// InfoContext writes info level messages with the IDs stored in the context as fields.
+func (r *Logger) InfoContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, logger.InfoLevel, v)
+}
+
+// Infof writes info level messages using formatted string.
+func (r *Logger) Infof(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.InfoLevel, 2, &format, v...)
+}
+
+// Level returns the minimum level of the recorded entries.
+func (r *Logger) Level() logger.Level {
+	return r.level
+}
+
+// Output records an entry with the fields of the logger and the caller at the calldepth, if the level is at least
+// the level of the logger.
+func (r *Logger) Output(level logger.Level, calldepth int, format *string, messages ...any) {
+	if r.level > level {
+		return
+	}
+
+	file, line := "???", 0
+	if _, path, pathLine, ok := runtime.Caller(calldepth); ok {
+		file, line = filepath.Base(path), pathLine
+	}
+
+	entry := logger.NewEntry(level, file, line, newMessage(format, messages))
+	entry.Fields = r.fields
+
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+
+	r.recorder.entries = append(r.recorder.entries, *entry)
+}
+
+// Reset removes the recorded entries and the expectations.
+func (r *Logger) Reset() {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+
+	r.recorder.entries = nil
+	r.recorder.expected = nil
+}
+
+// SetFileOutput does nothing, since the entries are recorded in memory.
+func (r *Logger) SetFileOutput(_ string) error {
+	return nil
+}
+
+// SetFormat changes the format returned by Format, if it's registered.
+func (r *Logger) SetFormat(format logger.Format) error {
+	if format.String() == "" {
+		return logger.ErrInvalidFormat
+	}
+
+	r.format = format
+
+	return nil
+}
+
+// SetLevel changes the minimum level of the recorded entries.
+func (r *Logger) SetLevel(level logger.Level) error {
+	if level < logger.DebugLevel || level > logger.FatalLevel {
+		return logger.ErrInvalidLevel
+	}
+
+	r.level = level
+
+	return nil
+}
+
+// SetOutput does nothing, since the entries are recorded in memory.
+func (r *Logger) SetOutput(_ io.Writer) {}
+
+// Warn writes warning level messages.
+func (r *Logger) Warn(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.WarnLevel, 2, nil, v...)
+}
+
+// WarnContext writes warning level messages with the IDs stored in the context as fields.
+func (r *Logger) WarnContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, logger.WarnLevel, v)
+}
+
+// Warnf writes warning level messages using formatted string.
+func (r *Logger) Warnf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.WarnLevel, 2, &format, v...)
+}
+
+// Warning is an alias for Warn.
+func (r *Logger) Warning(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.WarnLevel, 2, nil, v...)
+}
+
+// Warningf is an alias for Warnf.
+func (r *Logger) Warningf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(logger.WarnLevel, 2, &format, v...)
+}
+
+// With returns a child logger that records the alternating keys and values as fields of each entry.
+//
+//nolint:ireturn // Child loggers must satisfy the logger.Logger interface.
+func (r *Logger) With(keyValues ...any) logger.Logger {
+	return r.withFields(logger.NewFields(keyValues...))
+}
+
+// WithFields returns a child logger that records the fields with each entry.
+//
+//nolint:ireturn // Child loggers must satisfy the logger.Logger interface.
+func (r *Logger) WithFields(fields logger.Fields) logger.Logger {
+	return r.withFields(fields)
+}
+
+// failOnUnexpected fails the test if unexpected entries were recorded, see WithFailOnUnexpected.
+func (r *Logger) failOnUnexpected() {
+	r.recorder.mutex.Lock()
+	failLevel := r.recorder.failLevel
+	r.recorder.mutex.Unlock()
+
+	if failLevel == nil {
+		return
+	}
+
+	var unexpected []logger.Entry
+	for _, entry := range r.Entries() {
+		if entry.Level >= *failLevel && !r.isExpected(&entry) {
+			unexpected = append(unexpected, entry)
+		}
+	}
+
+	if len(unexpected) > 0 {
+		r.t.Errorf("loggertest: unexpected entries of at least %s level were logged:\n%s", failLevel.String(),
+			describeEntries(unexpected))
+	}
+}
+
+// find reports whether an entry of the level with the substring in its message was recorded.
+func (r *Logger) find(level logger.Level, substring string) bool {
+	return slices.ContainsFunc(r.Entries(level), func(entry logger.Entry) bool {
+		return strings.Contains(entry.String(), substring)
+	})
+}
+
+// isExpected reports whether the entry matches an expectation.
+func (r *Logger) isExpected(entry *logger.Entry) bool {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+
+	return slices.ContainsFunc(r.recorder.expected, func(expected expectation) bool {
+		return expected.level == entry.Level && strings.Contains(entry.String(), expected.substring)
+	})
+}
+
+// outputContext records the messages with the IDs stored in the context as fields.
+func (r *Logger) outputContext(ctx context.Context, level logger.Level, messages []any) {
+	log := r
+	if fields := logger.ContextFields(ctx); len(fields) > 0 {
+		log = r.withFields(fields)
+	}
+
+	//nolint:mnd // Skips over this method and the Context method in stack trace.
+	log.Output(level, 3, nil, messages...)
+}
+
+// withFields returns a child logger with the fields that shares the recorded entries.
+func (r *Logger) withFields(fields logger.Fields) *Logger {
+	child := *r
+	child.fields = r.fields.Merge(fields)
+
+	return &child
+}
+
+// describeEntries formats the entries for failure messages, one per line.
+func describeEntries(entries []logger.Entry) string {
+	if len(entries) == 0 {
+		return "\t(none)"
+	}
+
+	var builder strings.Builder
+
+	for i, entry := range entries {
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+
+		builder.WriteString("\t[")
+		builder.WriteString(entry.Level.String())
+		builder.WriteString("] ")
+		builder.WriteString(entry.File)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Itoa(entry.Line))
+		builder.WriteString(": ")
+		builder.WriteString(entry.String())
+
+		for _, key := range entry.Fields.Keys() {
+			_, _ = fmt.Fprintf(&builder, " %s=%v", key, entry.Fields[key])
+		}
+	}
+
+	return builder.String()
+}
+
+// newMessage returns the message of an entry like the loggers of the logger package: the formatted string, the
+// only message or the messages concatenated by fmt.Sprint.
+func newMessage(format *string, messages []any) any {
+	switch {
+	case format != nil:
+		return fmt.Sprintf(*format, messages...)
+	case len(messages) == 1:
+		return messages[0]
+	default:
+		return fmt.Sprint(messages...)
+	}
+}
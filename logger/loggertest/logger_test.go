@@ -0,0 +1,173 @@
+package loggertest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/spacecafe/gobox/logger/loggertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records the failures and the cleanup functions instead of failing the test.
+type fakeT struct {
+	testing.TB
+
+	errors   []string
+	cleanups []func()
+}
+
+func (r *fakeT) Cleanup(cleanup func()) {
+	r.cleanups = append(r.cleanups, cleanup)
+}
+
+func (r *fakeT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *fakeT) Helper() {}
+
+func (r *fakeT) finish() {
+	for _, cleanup := range r.cleanups {
+		cleanup()
+	}
+}
+
+func TestLogger_Entries(t *testing.T) {
+	t.Parallel()
+
+	log := loggertest.New(t, logger.WithLevel(logger.InfoLevel))
+
+	log.Debug("hidden message")
+	log.With("jobID", 42).Info("processing")
+	log.Warnf("retry %d", 2)
+	log.Errorf("failed")
+
+	entries := log.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "processing", entries[0].Message)
+	assert.Equal(t, logger.Fields{"jobID": 42}, entries[0].Fields)
+	assert.Equal(t, "logger_test.go", entries[0].File)
+	assert.Positive(t, entries[0].Line)
+
+	warnings := log.Entries(logger.WarnLevel)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "retry 2", warnings[0].Message)
+	assert.Len(t, log.Entries(logger.WarnLevel, logger.ErrorLevel), 2)
+
+	log.Reset()
+	assert.Empty(t, log.Entries())
+}
+
+func TestLogger_Context(t *testing.T) {
+	t.Parallel()
+
+	log := loggertest.New(t)
+
+	ctx := logger.ContextWithID(t.Context(), logger.RequestIDKey, "request-1")
+	log.InfoContext(ctx, "handling request")
+
+	entries := log.Entries(logger.InfoLevel)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "request-1", entries[0].Fields[logger.RequestIDKey])
+	assert.Equal(t, "logger_test.go", entries[0].File)
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	t.Parallel()
+
+	log := loggertest.New(t)
+
+	var continued bool
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		log.Fatal("failed to listen")
+		continued = true
+	}()
+	<-done
+
+	log.AssertLogged(t, logger.FatalLevel, "failed to listen")
+	assert.False(t, continued)
+}
+
+func TestLogger_AssertLogged(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeT{TB: t}
+	log := loggertest.New(fake)
+	log.Warn("disk almost full")
+
+	assert.True(t, log.AssertLogged(fake, logger.WarnLevel, "almost full"))
+	assert.False(t, log.AssertLogged(fake, logger.ErrorLevel, "almost full"))
+	assert.True(t, log.AssertNotLogged(fake, logger.ErrorLevel, "almost full"))
+	assert.False(t, log.AssertNotLogged(fake, logger.WarnLevel, "disk"))
+
+	require.Len(t, fake.errors, 2)
+	assert.Contains(t, fake.errors[0], `no error entry containing "almost full"`)
+	assert.Contains(t, fake.errors[0], "[warn] logger_test.go:")
+	assert.Contains(t, fake.errors[1], `unexpected warn entry containing "disk"`)
+}
+
+func TestWithFailOnUnexpected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		log        func(t testing.TB, log *loggertest.Logger)
+		wantFailed bool
+	}{
+		{
+			name: "info only",
+			log: func(_ testing.TB, log *loggertest.Logger) {
+				log.Info("started")
+			},
+		},
+		{
+			name: "unexpected warning",
+			log: func(_ testing.TB, log *loggertest.Logger) {
+				log.Warn("retrying")
+			},
+			wantFailed: true,
+		},
+		{
+			name: "expected error",
+			log: func(_ testing.TB, log *loggertest.Logger) {
+				log.Expect(logger.ErrorLevel, "connection refused")
+				log.With("attempt", 1).Error("connection refused")
+			},
+		},
+		{
+			name: "asserted warning",
+			log: func(t testing.TB, log *loggertest.Logger) {
+				log.Warn("retrying")
+				log.AssertLogged(t, logger.WarnLevel, "retrying")
+			},
+		},
+		{
+			name: "expectation of other level",
+			log: func(_ testing.TB, log *loggertest.Logger) {
+				log.Expect(logger.WarnLevel, "connection refused")
+				log.Error("connection refused")
+			},
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeT{TB: t}
+			log := loggertest.New(fake, loggertest.WithFailOnUnexpected(logger.WarnLevel))
+
+			tt.log(fake, log)
+			fake.finish()
+
+			assert.Equal(t, tt.wantFailed, len(fake.errors) > 0, fake.errors)
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+)
+
+// Sink is an output destination of a MultiLogger with its own format and minimum level.
+type Sink struct {
+	logger *log.Logger
+	file   io.Closer
+	async  *AsyncWriter
+	format Format
+	level  Level
+}
+
+// NewSink creates a Sink that writes entries of at least the given level in the given format to the writer.
+func NewSink(writer io.Writer, format Format, level Level) *Sink {
+	return &Sink{
+		logger: log.New(writer, "", 0),
+		format: format,
+		level:  level,
+	}
+}
+
+// NewSinkFromConfig creates a Sink from the configuration, which opens the output destination.
+func NewSinkFromConfig(cfg *SinkConfig) (*Sink, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	writer, file, err := openOutput(cfg.Output, &cfg.Rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := NewSink(writer, cfg.Format, cfg.Level)
+	sink.file = file
+
+	if err = sink.SetAsync(cfg.Async.BufferSize, cfg.Async.Policy); err != nil {
+		_ = sink.Close()
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// Close writes the buffered entries of the async mode and closes the file opened by NewSinkFromConfig.
+func (r *Sink) Close() error {
+	r.async, _ = setAsync(r.logger, r.async, 0, DropOldestPolicy)
+
+	if r.file == nil {
+		return nil
+	}
+
+	return r.file.Close() //nolint:wrapcheck // RotatingFile wraps its errors.
+}
+
+// Dropped returns the number of entries dropped by the async mode because its buffer was full.
+func (r *Sink) Dropped() uint64 {
+	if r.async == nil {
+		return 0
+	}
+
+	return r.async.Dropped()
+}
+
+// Flush blocks until the entries buffered by the async mode or the output destination are written or the
+// context is done.
+func (r *Sink) Flush(ctx context.Context) error {
+	return flushWriter(ctx, r.logger.Writer())
+}
+
+// Format returns the output format of the sink.
+func (r *Sink) Format() Format {
+	return r.format
+}
+
+// Level returns the minimum level of entries written by the sink.
+func (r *Sink) Level() Level {
+	return r.level
+}
+
+// SetAsync enables the async mode of the sink, see DefaultLogger.SetAsync.
+func (r *Sink) SetAsync(bufferSize int, policy OverflowPolicy) (err error) {
+	r.async, err = setAsync(r.logger, r.async, bufferSize, policy)
+
+	return err
+}
+
+// MultiLogger is a logger that fans out each entry to multiple sinks, e.g. plain text on stderr and JSON including
+// debug messages in a file. The caller information is computed once per entry and only written by sinks at
+// DebugLevel.
+type MultiLogger struct {
+	appName    string
+	name       string
+	sinks      *atomic.Pointer[[]*Sink]
+	fields     Fields
+	redactor   *Redactor
+	stackTrace bool
+	shutdown   *fatalShutdown
+}
+
+// Ensure MultiLogger implements the interfaces.
+var (
+	_ AdvancedLogger = (*MultiLogger)(nil)
+	_ ContextLogger  = (*MultiLogger)(nil)
+)
+
+// NewMultiLogger creates a MultiLogger with the given sinks. Without sinks, it writes plain text of at least
+// InfoLevel to stderr, like a DefaultLogger.
+func NewMultiLogger(sinks []*Sink, opts ...Option) *MultiLogger {
+	cfg := &Config{}
+	cfg.SetDefaults()
+
+	if len(sinks) == 0 {
+		sinks = []*Sink{NewSink(os.Stderr, cfg.Format, cfg.Level)}
+	}
+
+	logger := &MultiLogger{
+		appName: filepath.Base(os.Args[0]),
+		sinks:   &atomic.Pointer[[]*Sink]{},
+	}
+	logger.sinks.Store(&sinks)
+
+	for _, opt := range opts {
+		opt(logger)
+	}
+
+	return logger
+}
+
+// NewFromConfig creates a logger from the configuration: a MultiLogger if it has sinks, otherwise a DefaultLogger.
+// The configuration and the options are applied like by WithConfig.
+//
+//nolint:ireturn // The logger type depends on the configuration.
+func NewFromConfig(cfg *Config, opts ...Option) AdvancedLogger {
+	opts = append([]Option{WithConfig(cfg)}, opts...)
+
+	if len(cfg.Sinks) > 0 {
+		return NewMultiLogger(nil, opts...)
+	}
+
+	return New(opts...)
+}
+
+// Close writes the buffered entries and closes the files of all sinks, which are shared with child loggers.
+func (r *MultiLogger) Close() error {
+	sinks := r.currentSinks()
+	errs := make([]error, 0, len(sinks))
+	for _, sink := range sinks {
+		errs = append(errs, sink.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Debug writes debug level messages.
+func (r *MultiLogger) Debug(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(DebugLevel, 2, nil, v...)
+}
+
+// DebugContext writes debug level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *MultiLogger) DebugContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, DebugLevel, v)
+}
+
+// Debugf writes debug level messages using formatted string.
+func (r *MultiLogger) Debugf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(DebugLevel, 2, &format, v...)
+}
+
+// Dropped returns the number of entries dropped by the async mode of all sinks.
+func (r *MultiLogger) Dropped() uint64 {
+	var dropped uint64
+	for _, sink := range r.currentSinks() {
+		dropped += sink.Dropped()
+	}
+
+	return dropped
+}
+
+// Error writes error level messages.
+func (r *MultiLogger) Error(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(ErrorLevel, 2, nil, v...)
+}
+
+// ErrorContext writes error level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *MultiLogger) ErrorContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, ErrorLevel, v)
+}
+
+// Errorf writes error level messages using formatted string.
+func (r *MultiLogger) Errorf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(ErrorLevel, 2, &format, v...)
+}
+
+// Fatal writes fatal level messages and terminates the application, gracefully if set by WithShutdown.
+func (r *MultiLogger) Fatal(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(FatalLevel, 2, nil, v...)
+	r.shutdown.exit(r)
+}
+
+// Fatalf writes fatal level messages using formatted string and terminates the application, gracefully if set by
+// WithShutdown.
+func (r *MultiLogger) Fatalf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(FatalLevel, 2, &format, v...)
+	r.shutdown.exit(r)
+}
+
+// Flush blocks until the buffered entries of all sinks are written or the context is done.
+func (r *MultiLogger) Flush(ctx context.Context) error {
+	sinks := r.currentSinks()
+	errs := make([]error, 0, len(sinks))
+	for _, sink := range sinks {
+		errs = append(errs, sink.Flush(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Format returns the output format of the first sink.
+func (r *MultiLogger) Format() Format {
+	sinks := r.currentSinks()
+	if len(sinks) == 0 {
+		return PlainFormat
+	}
+
+	return sinks[0].format
+}
+
+// Info writes info level messages.
+func (r *MultiLogger) Info(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(InfoLevel, 2, nil, v...)
+}
+
+// InfoContext writes info level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *MultiLogger) InfoContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, InfoLevel, v)
+}
+
+// Infof writes info level messages using formatted string.
+func (r *MultiLogger) Infof(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(InfoLevel, 2, &format, v...)
+}
+
+// Level returns the lowest level of all sinks, i.e. the level of the most verbose sink.
+func (r *MultiLogger) Level() Level {
+	return components.effectiveLevel(r.name, r.configuredLevel())
+}
+
+// Named returns a child logger of the component, whose level can be overridden at runtime, see
+// DefaultLogger.Named. An override applies to all sinks.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *MultiLogger) Named(name string) Logger {
+	name = componentName(r.name, name)
+	child := r.withFields(Fields{ComponentKey: name})
+	child.name = name
+	components.register(name, child.configuredLevel())
+
+	return child
+}
+
+// Output creates an Entry and writes it to every sink whose level is lower than or equal to the given level.
+func (r *MultiLogger) Output(level Level, calldepth int, format *string, messages ...any) {
+	minLevel := r.Level()
+	if minLevel > level {
+		return
+	}
+
+	var (
+		file string
+		line int
+	)
+
+	if minLevel == DebugLevel {
+		file, line = callerLocation(calldepth)
+	}
+
+	entry := NewEntry(level, file, line, newMessage(format, r.redactor.RedactArgs(messages)))
+	entry.Fields = r.fields
+
+	if r.stackTrace && level >= ErrorLevel {
+		entry.Error = newErrorDetails(calldepth, messages, entry.Fields)
+	}
+
+	r.redactor.RedactEntry(entry)
+
+	// The entry without caller for sinks not at DebugLevel is only created if needed.
+	var withoutCaller *Entry
+
+	for _, sink := range r.currentSinks() {
+		sinkLevel := components.effectiveLevel(r.name, sink.level)
+		if sinkLevel > level {
+			continue
+		}
+
+		sinkEntry := entry
+		if sinkLevel != DebugLevel && entry.File != "" {
+			if withoutCaller == nil {
+				copied := *entry
+				copied.File, copied.Line = "", 0
+				withoutCaller = &copied
+			}
+			sinkEntry = withoutCaller
+		}
+
+		_ = sink.logger.Output(calldepth+1, formatEntry(sink.format, r.appName, sinkEntry))
+	}
+}
+
+// SetAsync enables the async mode of all sinks, see DefaultLogger.SetAsync.
+func (r *MultiLogger) SetAsync(bufferSize int, policy OverflowPolicy) error {
+	for _, sink := range r.currentSinks() {
+		if err := sink.SetAsync(bufferSize, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetFileOutput replaces all sinks by a single sink writing to the given file, see SetRotatingFileOutput.
+func (r *MultiLogger) SetFileOutput(filename string) error {
+	return r.SetRotatingFileOutput(filename, nil)
+}
+
+// SetFormat changes the output format of all sinks.
+func (r *MultiLogger) SetFormat(format Format) error {
+	if _, ok := lookupFormatter(format); !ok {
+		return ErrInvalidFormat
+	}
+
+	for _, sink := range r.currentSinks() {
+		sink.format = format
+	}
+
+	return nil
+}
+
+// SetLevel changes the minimum level of all sinks.
+func (r *MultiLogger) SetLevel(level Level) error {
+	if level < DebugLevel || level > FatalLevel {
+		return ErrInvalidLevel
+	}
+
+	for _, sink := range r.currentSinks() {
+		sink.level = level
+	}
+
+	if r.name != "" {
+		components.register(r.name, level)
+	}
+
+	return nil
+}
+
+// SetOutput replaces all sinks by a single sink writing to the writer with the current format and level.
+func (r *MultiLogger) SetOutput(writer io.Writer) {
+	_ = r.SetSinks([]*Sink{NewSink(writer, r.Format(), r.configuredLevel())})
+}
+
+// SetRedactor sets the Redactor that masks secrets and personal data in all entries. Nil disables the redaction.
+func (r *MultiLogger) SetRedactor(redactor *Redactor) {
+	r.redactor = redactor
+}
+
+// SetRotatingFileOutput replaces all sinks by a single sink writing to the given file with the current format
+// and level. The file is rotated according to the rotation.
+func (r *MultiLogger) SetRotatingFileOutput(filename string, rotation *RotationConfig) error {
+	writer, file, err := openOutput(filename, rotation)
+	if err != nil {
+		return err
+	}
+
+	sink := NewSink(writer, r.Format(), r.configuredLevel())
+	sink.file = file
+
+	return r.SetSinks([]*Sink{sink})
+}
+
+// SetShutdown sets the Shutdowner that terminates the application with the exit code on Fatal, e.g. a
+// terminator.Terminator. Nil exits immediately with FatalExitCode.
+func (r *MultiLogger) SetShutdown(shutdowner Shutdowner, exitCode int) {
+	if shutdowner == nil {
+		r.shutdown = nil
+		return
+	}
+
+	r.shutdown = &fatalShutdown{shutdowner: shutdowner, exitCode: exitCode}
+}
+
+// SetSinks replaces all sinks, which are shared with child loggers, and closes the files of the previous ones.
+func (r *MultiLogger) SetSinks(sinks []*Sink) error {
+	sinks = slices.Clone(sinks)
+	previous := *r.sinks.Swap(&sinks)
+
+	errs := make([]error, 0, len(previous))
+	for _, sink := range previous {
+		errs = append(errs, sink.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetSinksFromConfig replaces all sinks by the ones created from the configuration.
+func (r *MultiLogger) SetSinksFromConfig(cfgs []SinkConfig) error {
+	sinks := make([]*Sink, 0, len(cfgs))
+	for i := range cfgs {
+		sink, err := NewSinkFromConfig(&cfgs[i])
+		if err != nil {
+			for _, opened := range sinks {
+				_ = opened.Close()
+			}
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return r.SetSinks(sinks)
+}
+
+// SetStackTrace enables or disables capturing the stack trace and the error chain of ErrorLevel and FatalLevel
+// entries, see ErrorDetails.
+func (r *MultiLogger) SetStackTrace(enabled bool) {
+	r.stackTrace = enabled
+}
+
+// Sinks returns the sinks of the logger.
+func (r *MultiLogger) Sinks() []*Sink {
+	return slices.Clone(r.currentSinks())
+}
+
+// Warn writes warning level messages.
+func (r *MultiLogger) Warn(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, nil, v...)
+}
+
+// WarnContext writes warning level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *MultiLogger) WarnContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, WarnLevel, v)
+}
+
+// Warnf writes warning level messages using formatted string.
+func (r *MultiLogger) Warnf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, &format, v...)
+}
+
+// Warning writes warning level messages as an alias for the Warn method.
+func (r *MultiLogger) Warning(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, nil, v...)
+}
+
+// Warningf writes warning level messages using a formatted string. It acts as an alias for the Warnf method.
+func (r *MultiLogger) Warningf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, &format, v...)
+}
+
+// With returns a child logger that adds the alternating keys and values to each entry, see NewFields.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *MultiLogger) With(keyValues ...any) Logger {
+	return r.WithFields(NewFields(keyValues...))
+}
+
+// WithFields returns a child logger that adds the fields to each entry in addition to the fields of this logger.
+// The child shares the sinks of this logger.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *MultiLogger) WithFields(fields Fields) Logger {
+	return r.withFields(fields)
+}
+
+// configuredLevel returns the lowest level of all sinks, regardless of overrides.
+func (r *MultiLogger) configuredLevel() Level {
+	level := FatalLevel
+	for _, sink := range r.currentSinks() {
+		level = min(level, sink.level)
+	}
+
+	return level
+}
+
+// currentSinks returns the sinks shared by the logger and its children.
+func (r *MultiLogger) currentSinks() []*Sink {
+	return *r.sinks.Load()
+}
+
+// outputContext writes the messages with the IDs stored in the context as fields.
+func (r *MultiLogger) outputContext(ctx context.Context, level Level, messages []any) {
+	if r.Level() > level {
+		return
+	}
+
+	log := r
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		log = r.withFields(fields)
+	}
+
+	//nolint:mnd // Skips over this method and the calling Context method in stack trace.
+	log.Output(level, 3, nil, messages...)
+}
+
+// withFields returns a child logger with the fields, see WithFields.
+func (r *MultiLogger) withFields(fields Fields) *MultiLogger {
+	child := *r
+	child.fields = r.fields.Merge(fields)
+
+	return &child
+}
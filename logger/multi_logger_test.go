@@ -0,0 +1,187 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiLogger_Output(t *testing.T) {
+	t.Parallel()
+
+	var plain, structured bytes.Buffer
+	log := logger.NewMultiLogger([]*logger.Sink{
+		logger.NewSink(&plain, logger.PlainFormat, logger.InfoLevel),
+		logger.NewSink(&structured, logger.JSONFormat, logger.DebugLevel),
+	})
+
+	assert.Equal(t, logger.DebugLevel, log.Level())
+	assert.Equal(t, logger.PlainFormat, log.Format())
+
+	log.Debug("debug message")
+	log.With("jobID", "42").Info("info message")
+
+	assert.NotContains(t, plain.String(), "debug message")
+	assert.Contains(t, plain.String(), "info message jobID=42")
+	assert.NotContains(t, plain.String(), "multi_logger_test.go", "caller is only written by debug sinks")
+
+	lines := strings.Split(strings.TrimSpace(structured.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "info message", entry["message"])
+	assert.Equal(t, "42", entry["jobID"])
+	assert.Equal(t, "multi_logger_test.go", entry["file"])
+}
+
+func TestMultiLogger_WithConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &logger.Config{}
+	cfg.SetDefaults()
+	cfg.Sinks = []logger.SinkConfig{
+		{Level: logger.WarnLevel, Format: logger.PlainFormat, Output: filepath.Join(dir, "plain.log")},
+		{Level: logger.DebugLevel, Format: logger.JSONFormat, Output: filepath.Join(dir, "debug.log")},
+	}
+	require.NoError(t, cfg.Validate())
+
+	log := logger.NewMultiLogger(nil, logger.WithConfig(cfg))
+	require.Len(t, log.Sinks(), 2)
+
+	log.Debug("debug message")
+	log.Warn("warn message")
+	require.NoError(t, log.Close())
+
+	plain, err := os.ReadFile(filepath.Join(dir, "plain.log"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "debug message")
+	assert.Contains(t, string(plain), "warn message")
+
+	debug, err := os.ReadFile(filepath.Join(dir, "debug.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(debug), `"message":"debug message"`)
+	assert.Contains(t, string(debug), `"message":"warn message"`)
+}
+
+func TestMultiLogger_SetLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.NewMultiLogger(nil)
+	log.SetOutput(&buf)
+
+	require.NoError(t, log.SetLevel(logger.ErrorLevel))
+	require.ErrorIs(t, log.SetLevel(logger.Level(999)), logger.ErrInvalidLevel)
+	require.ErrorIs(t, log.SetFormat(logger.Format(999)), logger.ErrInvalidFormat)
+
+	log.Warn("ignored")
+	log.Error("written")
+
+	assert.NotContains(t, buf.String(), "ignored")
+	assert.Contains(t, buf.String(), "written")
+}
+
+func TestMultiLogger_SetSinksWithChildren(t *testing.T) {
+	t.Parallel()
+
+	log := logger.NewMultiLogger(nil)
+	child := log.With("jobID", 42)
+
+	filename := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, log.SetFileOutput(filename))
+	child.Info("file message")
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	child.Info("buffer message")
+
+	file, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(file), "file message")
+	assert.NotContains(t, string(file), "buffer message")
+	assert.Contains(t, buf.String(), "buffer message")
+	assert.Contains(t, buf.String(), "jobID=42")
+}
+
+func TestSinkConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     logger.SinkConfig
+		wantErr error
+	}{
+		{"valid", logger.SinkConfig{Output: logger.StderrOutput}, nil},
+		{"invalid level", logger.SinkConfig{Level: logger.Level(999), Output: logger.StderrOutput}, logger.ErrInvalidLevel},
+		{"invalid format", logger.SinkConfig{Format: logger.Format(999), Output: logger.StderrOutput}, logger.ErrInvalidFormat},
+		{"missing output", logger.SinkConfig{}, logger.ErrInvalidOutput},
+		{
+			"invalid rotation",
+			logger.SinkConfig{Output: logger.StderrOutput, Rotation: logger.RotationConfig{MaxSize: -1}},
+			logger.ErrInvalidRotation,
+		},
+		{
+			"invalid async buffer size",
+			logger.SinkConfig{Output: logger.StderrOutput, Async: logger.AsyncConfig{BufferSize: -1}},
+			logger.ErrInvalidAsync,
+		},
+		{
+			"invalid async policy",
+			logger.SinkConfig{Output: logger.StderrOutput, Async: logger.AsyncConfig{Policy: logger.OverflowPolicy(42)}},
+			logger.ErrInvalidOverflowPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, tt.cfg.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := &logger.Config{}
+	cfg.SetDefaults()
+	cfg.Output = filepath.Join(dir, "default.log")
+	require.NoError(t, cfg.Validate())
+
+	assert.IsType(t, &logger.DefaultLogger{}, logger.NewFromConfig(cfg))
+
+	cfg.Sinks = []logger.SinkConfig{
+		{Level: logger.InfoLevel, Format: logger.JSONFormat, Output: filepath.Join(dir, "sink.log")},
+	}
+	require.NoError(t, cfg.Validate())
+
+	log := logger.NewFromConfig(cfg, logger.WithStackTrace(true))
+	require.IsType(t, &logger.MultiLogger{}, log)
+	log.Info("info message")
+	require.NoError(t, log.(*logger.MultiLogger).Close())
+
+	sink, err := os.ReadFile(filepath.Join(dir, "sink.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sink), `"message":"info message"`)
+
+	// Loggers without sinks report them and write to the output.
+	defaultLog := logger.New(logger.WithConfig(cfg))
+	defaultLog.Info("info message")
+	require.NoError(t, defaultLog.Close())
+
+	output, err := os.ReadFile(cfg.Output)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "failed to set log sinks: "+logger.ErrNotSupported.Error())
+	assert.Contains(t, string(output), "info message")
+}
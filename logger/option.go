@@ -1,5 +1,39 @@
 package logger
 
+import (
+	"errors"
+)
+
+// sinkOutput is implemented by loggers with multiple sinks, e.g. MultiLogger.
+type sinkOutput interface {
+	SetSinksFromConfig(cfgs []SinkConfig) error
+}
+
+// asyncOutput is implemented by loggers that can write in the background, e.g. DefaultLogger.
+type asyncOutput interface {
+	SetAsync(bufferSize int, policy OverflowPolicy) error
+}
+
+// redactingLogger is implemented by loggers that can redact entries, e.g. DefaultLogger.
+type redactingLogger interface {
+	SetRedactor(redactor *Redactor)
+}
+
+// shutdownLogger is implemented by loggers that can shut down gracefully on Fatal, e.g. DefaultLogger.
+type shutdownLogger interface {
+	SetShutdown(shutdowner Shutdowner, exitCode int)
+}
+
+// stackTracingLogger is implemented by loggers that can capture stack traces, e.g. DefaultLogger.
+type stackTracingLogger interface {
+	SetStackTrace(enabled bool)
+}
+
+// rotatingFileOutput is implemented by loggers that can rotate their file output, e.g. DefaultLogger.
+type rotatingFileOutput interface {
+	SetRotatingFileOutput(filename string, rotation *RotationConfig) error
+}
+
 // Option is a functional option that can be applied to any logger implementation.
 // Implementations should accept these during construction.
 type Option func(ConfigurableLogger)
@@ -18,18 +52,102 @@ func WithFormat(format Format) Option {
 	}
 }
 
-// WithFileOutput sets the output destination.
+// WithFileOutput sets the output destination. Failures are reported to the current output destination.
 func WithFileOutput(filename string) Option {
 	return func(l ConfigurableLogger) {
-		_ = l.SetFileOutput(filename)
+		reportOutputError(l, l.SetFileOutput(filename))
+	}
+}
+
+// WithRotatingFileOutput sets the output destination, which is rotated according to the rotation if the logger
+// supports it. Failures are reported to the current output destination.
+func WithRotatingFileOutput(filename string, rotation *RotationConfig) Option {
+	return func(l ConfigurableLogger) {
+		if rotating, ok := l.(rotatingFileOutput); ok {
+			reportOutputError(l, rotating.SetRotatingFileOutput(filename, rotation))
+		} else {
+			reportOutputError(l, l.SetFileOutput(filename))
+		}
+	}
+}
+
+// WithAsync enables the async mode with a ring buffer of the given size, if the logger supports it.
+// If the buffer is full, the policy decides whether an entry is dropped or the call blocks.
+func WithAsync(bufferSize int, policy OverflowPolicy) Option {
+	return func(l ConfigurableLogger) {
+		if async, ok := l.(asyncOutput); ok {
+			_ = async.SetAsync(bufferSize, policy)
+		}
 	}
 }
 
-// WithConfig applies a full configuration.
+// WithRedaction masks secrets and personal data in all entries according to the configuration, if the logger
+// supports it. An invalid configuration is reported to the current output destination.
+func WithRedaction(cfg *RedactConfig) Option {
+	return func(l ConfigurableLogger) {
+		redacting, ok := l.(redactingLogger)
+		if !ok {
+			return
+		}
+
+		redactor, err := NewRedactor(cfg)
+		if err != nil {
+			l.Errorf("failed to set log redaction: %v", err)
+			return
+		}
+
+		redacting.SetRedactor(redactor)
+	}
+}
+
+// WithShutdown makes Fatal terminate the application by the Shutdowner with the exit code, if the logger supports
+// it, e.g. terminator.Terminator, so the running tasks are stopped gracefully. The buffered entries are written before.
+func WithShutdown(shutdowner Shutdowner, exitCode int) Option {
+	return func(l ConfigurableLogger) {
+		if shutdown, ok := l.(shutdownLogger); ok {
+			shutdown.SetShutdown(shutdowner, exitCode)
+		}
+	}
+}
+
+// WithStackTrace enables or disables capturing the stack trace and the error chain of ErrorLevel and FatalLevel
+// entries, if the logger supports it, see ErrorDetails.
+func WithStackTrace(enabled bool) Option {
+	return func(l ConfigurableLogger) {
+		if stackTracing, ok := l.(stackTracingLogger); ok {
+			stackTracing.SetStackTrace(enabled)
+		}
+	}
+}
+
+// WithConfig applies a full configuration. If the logger supports sinks and the configuration has some, they
+// replace the output. Other loggers report configured sinks as error and use the output instead, see NewFromConfig.
+// Failures to open the output are reported to the current output destination.
 func WithConfig(cfg *Config) Option {
 	return func(l ConfigurableLogger) {
+		WithRedaction(&cfg.Redact)(l)
+		WithStackTrace(cfg.StackTrace)(l)
+
+		sinks, ok := l.(sinkOutput)
+		if ok && len(cfg.Sinks) > 0 {
+			reportOutputError(l, sinks.SetSinksFromConfig(cfg.Sinks))
+			return
+		}
+
 		_ = l.SetLevel(cfg.Level)
 		_ = l.SetFormat(cfg.Format)
-		_ = l.SetFileOutput(cfg.Output)
+		WithRotatingFileOutput(cfg.Output, &cfg.Rotation)(l)
+		WithAsync(cfg.Async.BufferSize, cfg.Async.Policy)(l)
+
+		if len(cfg.Sinks) > 0 {
+			l.Errorf("failed to set log sinks: %v, using output %q instead", ErrNotSupported, cfg.Output)
+		}
+	}
+}
+
+// reportOutputError logs the error of setting the output destination, unless the logger doesn't support it.
+func reportOutputError(l ConfigurableLogger, err error) {
+	if err != nil && !errors.Is(err, ErrNotSupported) {
+		l.Errorf("failed to set log output: %v", err)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 )
@@ -12,6 +13,20 @@ const (
 	// PlainTimeFormat sets the format using Go's reference time.
 	PlainTimeFormat  = "2006-01-02 15:04:05"
 	SyslogTimeFormat = "2006-01-02T15:04:05.999999Z07:00"
+	LogfmtTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+	ECSTimeFormat    = "2006-01-02T15:04:05.000Z"
+
+	// GELFVersion is the version of the Graylog Extended Log Format written by GELFFormat.
+	GELFVersion = "1.1"
+
+	// ECSVersion is the version of the Elastic Common Schema written by ECSFormat.
+	ECSVersion = "8.11.0"
+
+	// StdoutOutput is the output destination of the standard output stream.
+	StdoutOutput = "/dev/stdout"
+
+	// StderrOutput is the output destination of the standard error stream.
+	StderrOutput = "/dev/stderr"
 )
 
 var (
@@ -28,10 +43,31 @@ var (
 		ErrorLevel: color.HiRedString(strings.ToUpper(LevelToString[ErrorLevel])),
 		FatalLevel: color.RedString(strings.ToUpper(LevelToString[FatalLevel])),
 	}
+
+	// hostname returns the hostname of the machine written by GELFFormat, or "localhost" if it's unknown.
+	//nolint:gochecknoglobals // The hostname is looked up once and cached.
+	hostname = sync.OnceValue(func() string {
+		host, err := os.Hostname()
+		if err != nil || host == "" {
+			return "localhost"
+		}
+
+		return host
+	})
 )
 
-// outputPlain is a wrapper function to create a log entry in plain text.
-func (r *DefaultLogger) outputPlain(entry *Entry, calldepth int) {
+// formatEntry returns the entry in the given format, or in PlainFormat if the format is not registered.
+func formatEntry(format Format, appName string, entry *Entry) string {
+	formatter, ok := lookupFormatter(format)
+	if !ok {
+		return formatPlain(entry)
+	}
+
+	return formatter(appName, entry)
+}
+
+// formatPlain returns the entry as plain text with a colored level prefix.
+func formatPlain(entry *Entry) string {
 	var builder strings.Builder
 
 	builder.WriteByte('[')
@@ -53,21 +89,24 @@ func (r *DefaultLogger) outputPlain(entry *Entry, calldepth int) {
 
 	builder.WriteString(": ")
 	builder.WriteString(entry.String())
+	writePlainFields(&builder, entry.Fields)
 
-	_ = r.logger.Output(calldepth, builder.String())
+	return builder.String()
 }
 
-// outputJSON is a wrapper function to create a log entry in JSON.
-func (r *DefaultLogger) outputJSON(entry *Entry, calldepth int) {
+// formatJSON returns the entry as JSON, or the error message if it cannot be marshaled.
+func formatJSON(entry *Entry) string {
 	text, err := entry.Marshal()
 	if err != nil {
-		_ = r.logger.Output(calldepth, err.Error())
-	} else {
-		_ = r.logger.Output(calldepth, string(text))
+		return err.Error()
 	}
+
+	return string(text)
 }
 
-func (r *DefaultLogger) outputSyslog(entry *Entry, calldepth int) {
+// formatSyslog returns the entry in the RFC 5424 syslog format.
+// The caller and the fields are written as structured data.
+func formatSyslog(appName string, entry *Entry) string {
 	var builder strings.Builder
 
 	builder.WriteByte('<')
@@ -80,24 +119,113 @@ func (r *DefaultLogger) outputSyslog(entry *Entry, calldepth int) {
 
 	// Skip HOSTNAME
 	builder.WriteString(" - ")
-	builder.WriteString(r.appName)
+	builder.WriteString(appName)
 
 	// Skip PROCID and MSGID
 	builder.WriteString(" - - ")
 
-	if entry.File != "" {
-		builder.WriteString(`[goSDID@32473 file="`)
-		builder.WriteString(entry.File)
-		builder.WriteString(`" line="`)
-		builder.WriteString(strconv.Itoa(entry.Line))
-		builder.WriteString(`"] `)
+	if entry.File != "" || len(entry.Fields) > 0 {
+		builder.WriteString("[" + SyslogSDID)
+		if entry.File != "" {
+			builder.WriteString(` file="`)
+			builder.WriteString(EscapeSyslogParamValue(entry.File))
+			builder.WriteString(`" line="`)
+			builder.WriteString(strconv.Itoa(entry.Line))
+			builder.WriteByte('"')
+		}
+		writeSyslogParams(&builder, entry.Fields)
+		builder.WriteString("] ")
 	} else {
 		builder.WriteString("- ")
 	}
 
 	builder.WriteString(EscapeSyslogMessage(entry.String()))
 
-	_ = r.logger.Output(calldepth, builder.String())
+	return builder.String()
+}
+
+// formatLogfmt returns the entry as logfmt key/value pairs with the keys ts, level, app, caller and msg, followed
+// by the fields.
+func formatLogfmt(appName string, entry *Entry) string {
+	var builder strings.Builder
+
+	builder.WriteString("ts=")
+	builder.WriteString(entry.Date.Format(LogfmtTimeFormat))
+	builder.WriteString(" level=")
+	builder.WriteString(LevelToString[entry.Level])
+	builder.WriteString(" app=")
+	builder.WriteString(quotePlainValue(appName))
+
+	if entry.File != "" {
+		builder.WriteString(" caller=")
+		builder.WriteString(quotePlainValue(entry.File + ":" + strconv.Itoa(entry.Line)))
+	}
+
+	builder.WriteString(" msg=")
+	builder.WriteString(quotePlainValue(entry.String()))
+	writeLogfmtFields(&builder, entry.Fields)
+
+	return builder.String()
+}
+
+// formatGELF returns the entry in the Graylog Extended Log Format 1.1. The hostname of the machine is written as
+// host, the level as syslog severity, the stack trace as full_message and the application name, the caller and the
+// fields as additional fields, e.g. "_app" and "_file".
+func formatGELF(appName string, entry *Entry) string {
+	var builder strings.Builder
+
+	builder.WriteByte('{')
+	writeJSONMember(&builder, "version", GELFVersion)
+	writeJSONMember(&builder, "host", hostname())
+	writeJSONMember(&builder, "short_message", entry.String())
+	writeJSONMember(&builder, "timestamp", float64(entry.Date.UnixMilli())/1000) //nolint:mnd // Milliseconds.
+	writeJSONMember(&builder, "level", LevelToSyslog[entry.Level])
+	writeJSONMember(&builder, "_app", appName)
+
+	if entry.Error != nil {
+		writeJSONMember(&builder, "full_message", entry.Error.String())
+	}
+
+	if entry.File != "" {
+		writeJSONMember(&builder, "_file", entry.File)
+		writeJSONMember(&builder, "_line", entry.Line)
+	}
+
+	writeGELFFields(&builder, entry.Fields)
+	builder.WriteByte('}')
+
+	return builder.String()
+}
+
+// formatECS returns the entry as JSON following the Elastic Common Schema. The application name is written as
+// service.name, the caller as log.origin.file and the stack trace as error.stack_trace. The fields are written as
+// top-level keys.
+func formatECS(appName string, entry *Entry) string {
+	var builder strings.Builder
+
+	builder.WriteByte('{')
+	writeJSONMember(&builder, "@timestamp", entry.Date.UTC().Format(ECSTimeFormat))
+	writeJSONMember(&builder, "log.level", LevelToString[entry.Level])
+	writeJSONMember(&builder, "message", entry.String())
+	writeJSONMember(&builder, "ecs.version", ECSVersion)
+	writeJSONMember(&builder, "service.name", appName)
+
+	if entry.File != "" {
+		writeJSONMember(&builder, "log.origin.file.name", entry.File)
+		writeJSONMember(&builder, "log.origin.file.line", entry.Line)
+	}
+
+	if entry.Error != nil {
+		writeJSONMember(&builder, "error.stack_trace", entry.Error.String())
+		if len(entry.Error.Chain) > 0 {
+			writeJSONMember(&builder, "error.type", entry.Error.Chain[0].Type)
+		}
+	}
+
+	writeECSFields(&builder, entry.Fields)
+	builder.WriteByte('}')
+
+	return builder.String()
 }
 
 // EscapeSyslogMessage escapes special characters in syslog messages
@@ -0,0 +1,398 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// RedactedValue replaces the redacted values and matches.
+	RedactedValue = "[REDACTED]"
+
+	// RedactTag is the value of the struct tag `logger:"redact"` that marks fields whose values are redacted when
+	// the struct is logged as a message argument or a field value.
+	RedactTag = "redact"
+
+	// redactTagKey is the key of the struct tag holding RedactTag.
+	redactTagKey = "logger"
+)
+
+// redactTypes caches whether a struct type has fields with RedactTag, including nested structs.
+//
+//nolint:gochecknoglobals // The cache of the struct types must be shared by all redactors.
+var redactTypes sync.Map
+
+// DefaultRedactFields returns the field name patterns of common secrets and personal data, see RedactConfig.
+func DefaultRedactFields() []string {
+	return []string{
+		"*password*", "*passwd*", "*secret*", "*token*", "*apikey*", "*api_key*", "*api-key*",
+		"authorization", "cookie", "set-cookie", "*email*",
+	}
+}
+
+// DefaultRedactPatterns returns the regular expressions of common secrets and personal data in messages, i.e.
+// JWTs, credentials of Authorization headers, secrets in key/value pairs and email addresses, see RedactConfig.
+func DefaultRedactPatterns() []string {
+	return []string{
+		`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`,
+		`(?i)\bauthorization:\s*(?:(?:bearer|basic|token)\s+)?(\S+)`,
+		`(?i)\b(?:password|passwd|secret|token|api[_-]?key)=([^\s&]+)`,
+		`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	}
+}
+
+// Redactor masks secrets and personal data in log entries before they are formatted, so it applies to all
+// formats. It replaces
+//   - the values of fields whose names match a pattern,
+//   - the matches of regular expressions in messages and string field values,
+//   - the values of struct fields tagged with `logger:"redact"`, if the struct is logged.
+//
+// A nil Redactor doesn't redact anything.
+type Redactor struct {
+	fields   []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a Redactor from the configuration.
+func NewRedactor(cfg *RedactConfig) (*Redactor, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	redactor := &Redactor{
+		fields:   make([]string, 0, len(cfg.Fields)),
+		patterns: make([]*regexp.Regexp, 0, len(cfg.Patterns)),
+	}
+
+	for _, field := range cfg.Fields {
+		redactor.fields = append(redactor.fields, strings.ToLower(field))
+	}
+
+	for _, pattern := range cfg.Patterns {
+		redactor.patterns = append(redactor.patterns, regexp.MustCompile(pattern))
+	}
+
+	return redactor, nil
+}
+
+// RedactArgs returns the message arguments with structs having fields tagged with `logger:"redact"` replaced by
+// a wrapper that formats them with redacted values. The given slice is not modified.
+func (r *Redactor) RedactArgs(args []any) []any {
+	if r == nil {
+		return args
+	}
+
+	var redacted []any
+	for i, arg := range args {
+		value := redactStruct(arg)
+		if redacted == nil {
+			if value == arg {
+				continue
+			}
+			redacted = slices.Clone(args)
+		}
+		redacted[i] = value
+	}
+
+	if redacted == nil {
+		return args
+	}
+
+	return redacted
+}
+
+// RedactEntry redacts the message, the fields and the messages of the error chain of the entry.
+// The fields of the entry are replaced, not modified, since they are shared with the logger.
+func (r *Redactor) RedactEntry(entry *Entry) {
+	if r == nil {
+		return
+	}
+
+	entry.Message = r.redactFieldValue(entry.Message)
+
+	if entry.Error != nil && len(entry.Error.Chain) > 0 {
+		details := *entry.Error
+		details.Chain = make([]ChainedError, len(entry.Error.Chain))
+		for i, chained := range entry.Error.Chain {
+			details.Chain[i] = ChainedError{Type: chained.Type, Message: r.RedactString(chained.Message)}
+		}
+		entry.Error = &details
+	}
+
+	if len(entry.Fields) == 0 {
+		return
+	}
+
+	fields := make(Fields, len(entry.Fields))
+	for key, value := range entry.Fields {
+		if r.isSensitiveField(key) {
+			fields[key] = RedactedValue
+		} else {
+			fields[key] = r.redactFieldValue(value)
+		}
+	}
+	entry.Fields = fields
+}
+
+// RedactString replaces the matches of the patterns in the text. If a pattern has capture groups, only the
+// captured text is replaced.
+func (r *Redactor) RedactString(text string) string {
+	if r == nil {
+		return text
+	}
+
+	for _, pattern := range r.patterns {
+		text = maskMatches(pattern, text)
+	}
+
+	return text
+}
+
+// isSensitiveField reports whether the field name matches one of the field name patterns, ignoring the case.
+func (r *Redactor) isSensitiveField(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, pattern := range r.fields {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactFieldValue redacts strings, error messages and structs with tagged fields.
+func (r *Redactor) redactFieldValue(value any) any {
+	switch typed := value.(type) {
+	case string:
+		return r.RedactString(typed)
+	case error:
+		if message := r.RedactString(typed.Error()); message != typed.Error() {
+			return message
+		}
+
+		return value
+	default:
+		return redactStruct(value)
+	}
+}
+
+// redactedStruct formats a struct, or a pointer to it, like the fmt and encoding/json packages, but with the values
+// of fields tagged with `logger:"redact"` replaced by RedactedValue.
+type redactedStruct struct {
+	value   reflect.Value
+	pointer bool
+}
+
+// Format implements fmt.Formatter like the verbs %v and %+v.
+func (r redactedStruct) Format(state fmt.State, verb rune) {
+	if r.pointer {
+		_, _ = io.WriteString(state, "&")
+	}
+	_, _ = io.WriteString(state, "{")
+
+	typ := r.value.Type()
+	for i := range typ.NumField() {
+		if i > 0 {
+			_, _ = io.WriteString(state, " ")
+		}
+
+		field := typ.Field(i)
+		if state.Flag('+') {
+			_, _ = io.WriteString(state, field.Name+":")
+		}
+
+		switch {
+		case isRedactedField(&field):
+			_, _ = io.WriteString(state, RedactedValue)
+		case field.IsExported():
+			_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), redactStruct(r.value.Field(i).Interface()))
+		default:
+			// The fmt package prints the value of unexported fields held by a reflect.Value.
+			_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), r.value.Field(i))
+		}
+	}
+
+	_, _ = io.WriteString(state, "}")
+}
+
+// MarshalJSON implements json.Marshaler with the names of the json struct tags. Embedded structs of exported types
+// without a name are flattened like by the encoding/json package, unexported ones are skipped.
+func (r redactedStruct) MarshalJSON() ([]byte, error) {
+	var builder strings.Builder
+	builder.WriteByte('{')
+
+	if _, err := r.writeJSONFields(&builder, r.value, false); err != nil {
+		return nil, err
+	}
+
+	builder.WriteByte('}')
+
+	return []byte(builder.String()), nil
+}
+
+// writeJSONFields writes the exported fields of the struct as JSON object members, separated by commas from the
+// previous ones if separate is set. It reports whether members were written, so the next ones must be separated.
+func (r redactedStruct) writeJSONFields(builder *strings.Builder, value reflect.Value, separate bool) (bool, error) {
+	typ := value.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if !field.IsExported() || (name == "-" && opts == "") {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Anonymous && name == "" && fieldValue.Kind() == reflect.Struct {
+			var err error
+			if separate, err = r.writeJSONFields(builder, fieldValue, separate); err != nil {
+				return separate, err
+			}
+			continue
+		}
+
+		if slices.Contains(strings.Split(opts, ","), "omitempty") && fieldValue.IsZero() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		var member any = RedactedValue
+		if !isRedactedField(&field) {
+			member = redactStruct(fieldValue.Interface())
+		}
+
+		encodedName, _ := json.Marshal(name)
+		encodedValue, err := json.Marshal(member)
+		if err != nil {
+			return separate, fmt.Errorf("failed to marshal field %q: %w", name, err)
+		}
+
+		if separate {
+			builder.WriteByte(',')
+		}
+		separate = true
+
+		builder.Write(encodedName)
+		builder.WriteByte(':')
+		builder.Write(encodedValue)
+	}
+
+	return separate, nil
+}
+
+// maskMatches replaces the matches of the pattern, or their captured text, in the text by RedactedValue.
+func maskMatches(pattern *regexp.Regexp, text string) string {
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var builder strings.Builder
+	last := 0
+
+	for _, match := range matches {
+		spans := [][]int{match[:2]}
+		if len(match) > 2 { //nolint:mnd // The first two indices are the whole match.
+			spans = spans[:0]
+			for i := 2; i+1 < len(match); i += 2 {
+				if match[i] >= 0 {
+					spans = append(spans, match[i:i+2])
+				}
+			}
+		}
+
+		for _, span := range spans {
+			if span[0] < last {
+				// Nested capture groups are already replaced.
+				continue
+			}
+
+			builder.WriteString(text[last:span[0]])
+			builder.WriteString(RedactedValue)
+			last = span[1]
+		}
+	}
+
+	builder.WriteString(text[last:])
+
+	return builder.String()
+}
+
+// redactStruct wraps a struct, or a pointer to it, that has fields tagged with `logger:"redact"` in a
+// redactedStruct. Other values and types formatting themselves, e.g. by implementing fmt.Stringer, are returned
+// unchanged.
+func redactStruct(value any) any {
+	switch value.(type) {
+	case nil, fmt.Formatter, fmt.Stringer, error, json.Marshaler:
+		return value
+	}
+
+	reflected := reflect.ValueOf(value)
+	pointer := reflected.Kind() == reflect.Pointer
+	if pointer {
+		if reflected.IsNil() {
+			return value
+		}
+		reflected = reflected.Elem()
+	}
+
+	if reflected.Kind() != reflect.Struct || !hasRedactedFields(reflected.Type()) {
+		return value
+	}
+
+	return redactedStruct{value: reflected, pointer: pointer}
+}
+
+// hasRedactedFields reports whether the struct type or one of its nested structs has fields tagged with
+// `logger:"redact"`. The result is cached per type.
+func hasRedactedFields(typ reflect.Type) bool {
+	if cached, ok := redactTypes.Load(typ); ok {
+		return cached.(bool) //nolint:forcetypeassert // The cache only holds booleans.
+	}
+
+	found := findRedactedFields(typ, make(map[reflect.Type]bool))
+	redactTypes.Store(typ, found)
+
+	return found
+}
+
+// findRedactedFields searches the struct type and its nested structs for fields tagged with `logger:"redact"`.
+// The visited types prevent endless recursion of self-referencing types.
+func findRedactedFields(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	visited[typ] = true
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if isRedactedField(&field) {
+			return true
+		}
+
+		nested := field.Type
+		if nested.Kind() == reflect.Pointer {
+			nested = nested.Elem()
+		}
+
+		if nested.Kind() == reflect.Struct && !visited[nested] && findRedactedFields(nested, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRedactedField reports whether the struct field is tagged with `logger:"redact"`.
+func isRedactedField(field *reflect.StructField) bool {
+	return slices.Contains(strings.Split(field.Tag.Get(redactTagKey), ","), RedactTag)
+}
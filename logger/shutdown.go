@@ -0,0 +1,27 @@
+package logger
+
+// FatalExitCode is the exit code of the application after Fatal, unless another one is set by WithShutdown.
+const FatalExitCode = 1
+
+// fatalShutdown terminates the application after Fatal by a Shutdowner with the exit code.
+type fatalShutdown struct {
+	shutdowner Shutdowner
+	exitCode   int
+}
+
+// exit writes the buffered entries and terminates the application. If no shutdown is set, i.e. it's nil, the
+// application exits immediately with FatalExitCode.
+func (r *fatalShutdown) exit(flusher Flusher) {
+	flushBeforeExit(flusher)
+
+	if r == nil {
+		OsExit(FatalExitCode)
+		return
+	}
+
+	r.shutdowner.Shutdown(r.exitCode)
+
+	// The Shutdowner should not return, but the application must terminate anyway.
+	flushBeforeExit(flusher)
+	OsExit(r.exitCode)
+}
@@ -0,0 +1,105 @@
+package logger_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingShutdowner records the exit codes and the log output at the time of the shutdown.
+type recordingShutdowner struct {
+	output *bytes.Buffer
+	codes  []int
+	logged []string
+}
+
+func (r *recordingShutdowner) Shutdown(exitCode int) {
+	r.codes = append(r.codes, exitCode)
+	r.logged = append(r.logged, r.output.String())
+}
+
+//nolint:paralleltest // This test mocks OsExit.
+func TestWithShutdown(t *testing.T) {
+	previous := logger.OsExit
+	t.Cleanup(func() {
+		logger.OsExit = previous
+	})
+
+	var exitCodes []int
+	logger.OsExit = func(code int) {
+		exitCodes = append(exitCodes, code)
+	}
+
+	tests := []struct {
+		name      string
+		newLogger func(buf *bytes.Buffer, shutdowner logger.Shutdowner) logger.Logger
+	}{
+		{
+			name: "default logger",
+			newLogger: func(buf *bytes.Buffer, shutdowner logger.Shutdowner) logger.Logger {
+				log := logger.New(logger.WithShutdown(shutdowner, 3), logger.WithAsync(4, logger.BlockPolicy))
+				log.SetOutput(buf)
+
+				return log.With("jobID", 42)
+			},
+		},
+		{
+			name: "multi logger",
+			newLogger: func(buf *bytes.Buffer, shutdowner logger.Shutdowner) logger.Logger {
+				log := logger.NewMultiLogger([]*logger.Sink{logger.NewSink(buf, logger.PlainFormat, logger.InfoLevel)})
+				logger.WithShutdown(shutdowner, 3)(log)
+
+				return log
+			},
+		},
+		{
+			name: "slog logger",
+			newLogger: func(buf *bytes.Buffer, shutdowner logger.Shutdowner) logger.Logger {
+				return logger.NewSlogLogger(slog.NewTextHandler(buf, nil), logger.WithShutdown(shutdowner, 3))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCodes = nil
+
+			var buf bytes.Buffer
+			shutdowner := &recordingShutdowner{output: &buf}
+			log := tt.newLogger(&buf, shutdowner)
+
+			log.Fatal("failed to listen") //nolint:revive // OsExit and the shutdown are mocked.
+			log.Fatalf("failed to %s", "serve")
+
+			assert.Equal(t, []int{3, 3}, shutdowner.codes)
+			assert.Contains(t, shutdowner.logged[0], "failed to listen")
+			assert.Contains(t, shutdowner.logged[1], "failed to serve")
+
+			// The exit code is used, if the shutdown returns.
+			assert.Equal(t, []int{3, 3}, exitCodes)
+		})
+	}
+}
+
+//nolint:paralleltest // This test mocks OsExit.
+func TestWithShutdown_Nil(t *testing.T) {
+	previous := logger.OsExit
+	t.Cleanup(func() {
+		logger.OsExit = previous
+	})
+
+	var exitCode int
+	logger.OsExit = func(code int) {
+		exitCode = code
+	}
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithShutdown(&recordingShutdowner{output: &buf}, 3), logger.WithShutdown(nil, 3))
+	log.SetOutput(&buf)
+
+	log.Fatal("failed to listen") //nolint:revive // OsExit is mocked.
+	assert.Equal(t, logger.FatalExitCode, exitCode)
+}
@@ -0,0 +1,194 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+const (
+	// SlogLevelFatal is the slog level that corresponds to FatalLevel.
+	SlogLevelFatal = slog.LevelError + 4
+
+	// slogCalldepth is the calldepth of the caller of a slog.Logger method seen from AdvancedLogger.Output,
+	// i.e. Output, SlogHandler.Handle, slog.Logger.log and slog.Logger.Info.
+	slogCalldepth = 4
+
+	// slogMaxCalldepth limits the stack frames searched for the caller of a slog.Record.
+	slogMaxCalldepth = 32
+)
+
+// SlogHandler is a slog.Handler that writes records to an AdvancedLogger, so a *slog.Logger can share the
+// logging pipeline of this package. Attributes are written as fields; keys of groups are joined by dots,
+// e.g. "request.id".
+type SlogHandler struct {
+	log   AdvancedLogger
+	group string
+}
+
+// Ensure SlogHandler implements the interface.
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler creates a slog.Handler that writes to the given logger.
+func NewSlogHandler(log AdvancedLogger) *SlogHandler {
+	return &SlogHandler{log: log}
+}
+
+// NewSlog creates a *slog.Logger that writes to the given logger.
+func NewSlog(log AdvancedLogger) *slog.Logger {
+	return slog.New(NewSlogHandler(log))
+}
+
+// Enabled reports whether the logger writes records of the given level.
+func (r *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return r.log.Level() <= LevelFromSlog(level)
+}
+
+// Handle writes the record with its attributes as fields. The caller is taken from the record, if the logger
+// records callers.
+func (r *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := make(Fields, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttrField(fields, r.group, attr)
+
+		return true
+	})
+
+	log := r.log
+	if len(fields) > 0 {
+		log = withAdvancedFields(log, fields)
+	}
+
+	log.Output(LevelFromSlog(record.Level), r.calldepth(record.PC), nil, record.Message)
+
+	return nil
+}
+
+// WithAttrs returns a handler whose logger adds the attributes as fields to each entry.
+//
+//nolint:ireturn // The slog.Handler interface requires to return a slog.Handler.
+func (r *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(Fields, len(attrs))
+	for _, attr := range attrs {
+		addAttrField(fields, r.group, attr)
+	}
+
+	return &SlogHandler{log: withAdvancedFields(r.log, fields), group: r.group}
+}
+
+// WithGroup returns a handler that prefixes the keys of subsequent attributes with the group name.
+//
+//nolint:ireturn // The slog.Handler interface requires to return a slog.Handler.
+func (r *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return r
+	}
+
+	return &SlogHandler{log: r.log, group: joinFieldKey(r.group, name)}
+}
+
+// calldepth returns the calldepth of the frame with the program counter seen from AdvancedLogger.Output,
+// which is called by Handle. If the frame is not found, the calldepth of a slog.Logger method is assumed.
+func (r *SlogHandler) calldepth(pc uintptr) int {
+	if pc == 0 {
+		return slogCalldepth
+	}
+
+	// Skip runtime.Callers and this method, so the first frame is Handle.
+	pcs := make([]uintptr, slogMaxCalldepth)
+	size := runtime.Callers(2, pcs) //nolint:mnd // Skips runtime.Callers and this method.
+	for i, candidate := range pcs[:size] {
+		if candidate == pc {
+			// Output is one frame below Handle.
+			return i + 1
+		}
+	}
+
+	return slogCalldepth
+}
+
+// SlogLevel converts a Level to the corresponding slog.Level.
+func SlogLevel(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	default:
+		return SlogLevelFatal
+	}
+}
+
+// LevelFromSlog converts a slog.Level to the Level covering it, e.g. slog.LevelInfo+2 becomes InfoLevel.
+func LevelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	case level < SlogLevelFatal:
+		return ErrorLevel
+	default:
+		return FatalLevel
+	}
+}
+
+// addAttrField adds the resolved attribute to the fields. The keys of groups are joined by dots; groups without
+// a key are inlined and empty attributes are ignored, as required by slog.Handler.
+func addAttrField(fields Fields, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		prefix = joinFieldKey(prefix, attr.Key)
+		for _, member := range attr.Value.Group() {
+			addAttrField(fields, prefix, member)
+		}
+
+		return
+	}
+
+	fields[joinFieldKey(prefix, attr.Key)] = attr.Value.Any()
+}
+
+// fieldAttrs converts the fields to slog attributes in the order of their keys.
+func fieldAttrs(fields Fields) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, key := range fields.Keys() {
+		attrs = append(attrs, slog.Any(key, fields[key]))
+	}
+
+	return attrs
+}
+
+// withAdvancedFields returns a child of the logger with the fields. If the child is no AdvancedLogger,
+// the logger itself is returned and the fields are dropped.
+//
+//nolint:ireturn // The logger could be any AdvancedLogger implementation.
+func withAdvancedFields(log AdvancedLogger, fields Fields) AdvancedLogger {
+	if child, ok := log.WithFields(fields).(AdvancedLogger); ok {
+		return child
+	}
+
+	return log
+}
+
+// joinFieldKey appends a key to a dotted prefix of a group.
+func joinFieldKey(prefix, key string) string {
+	switch {
+	case key == "":
+		return prefix
+	case prefix == "":
+		return key
+	default:
+		return prefix + "." + key
+	}
+}
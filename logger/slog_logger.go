@@ -0,0 +1,255 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogLogger is an AdvancedLogger that writes to a slog.Handler, so code using this package can share the
+// logging pipeline of a *slog.Logger. Fields are written as attributes.
+//
+// The format and the output destination are determined by the handler, so they cannot be changed.
+type SlogLogger struct {
+	handler  slog.Handler
+	level    Level
+	shutdown *fatalShutdown
+}
+
+// Ensure SlogLogger implements the interfaces.
+var (
+	_ AdvancedLogger = (*SlogLogger)(nil)
+	_ ContextLogger  = (*SlogLogger)(nil)
+)
+
+// NewSlogLogger creates a logger that writes to the given slog.Handler.
+func NewSlogLogger(handler slog.Handler, opts ...Option) *SlogLogger {
+	cfg := &Config{}
+	cfg.SetDefaults()
+
+	logger := &SlogLogger{
+		handler: handler,
+		level:   cfg.Level,
+	}
+
+	for _, opt := range opts {
+		opt(logger)
+	}
+
+	return logger
+}
+
+// Debug writes debug level messages.
+func (r *SlogLogger) Debug(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(DebugLevel, 2, nil, v...)
+}
+
+// DebugContext writes debug level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *SlogLogger) DebugContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, DebugLevel, v)
+}
+
+// Debugf writes debug level messages using formatted string.
+func (r *SlogLogger) Debugf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(DebugLevel, 2, &format, v...)
+}
+
+// Error writes error level messages.
+func (r *SlogLogger) Error(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(ErrorLevel, 2, nil, v...)
+}
+
+// ErrorContext writes error level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *SlogLogger) ErrorContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, ErrorLevel, v)
+}
+
+// Errorf writes error level messages using formatted string.
+func (r *SlogLogger) Errorf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(ErrorLevel, 2, &format, v...)
+}
+
+// Fatal writes fatal level messages and terminates the application, gracefully if set by WithShutdown.
+func (r *SlogLogger) Fatal(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(FatalLevel, 2, nil, v...)
+	r.shutdown.exit(r)
+}
+
+// Fatalf writes fatal level messages using formatted string and terminates the application, gracefully if set by
+// WithShutdown.
+func (r *SlogLogger) Fatalf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(FatalLevel, 2, &format, v...)
+	r.shutdown.exit(r)
+}
+
+// Flush blocks until the records buffered by the handler are written or the context is done, if the handler is a
+// Flusher.
+func (r *SlogLogger) Flush(ctx context.Context) error {
+	if flusher, ok := r.handler.(Flusher); ok {
+		return flusher.Flush(ctx) //nolint:wrapcheck // The handler is responsible for its errors.
+	}
+
+	return nil
+}
+
+// Format returns PlainFormat, since the format is determined by the handler.
+func (r *SlogLogger) Format() Format {
+	return PlainFormat
+}
+
+// Handler returns the slog.Handler the logger writes to.
+func (r *SlogLogger) Handler() slog.Handler {
+	return r.handler
+}
+
+// Info writes info level messages.
+func (r *SlogLogger) Info(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(InfoLevel, 2, nil, v...)
+}
+
+// InfoContext writes info level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *SlogLogger) InfoContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, InfoLevel, v)
+}
+
+// Infof writes info level messages using formatted string.
+func (r *SlogLogger) Infof(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(InfoLevel, 2, &format, v...)
+}
+
+// Level returns the current logging level of the logger, which is overridden by an override of AllComponents, see
+// SetLevelOverride.
+func (r *SlogLogger) Level() Level {
+	return components.effectiveLevel("", r.level)
+}
+
+// Output passes a record with the level, message and the caller at the calldepth to the handler.
+// Records below the level of the logger or not enabled by the handler are discarded.
+func (r *SlogLogger) Output(level Level, calldepth int, format *string, messages ...any) {
+	r.output(context.Background(), level, calldepth+1, format, messages)
+}
+
+// SetFileOutput is not supported, since the output destination is determined by the handler.
+func (r *SlogLogger) SetFileOutput(_ string) error {
+	return ErrNotSupported
+}
+
+// SetFormat is not supported, since the format is determined by the handler.
+func (r *SlogLogger) SetFormat(_ Format) error {
+	return ErrNotSupported
+}
+
+// SetLevel changes the logging level of the logger to the specified level.
+func (r *SlogLogger) SetLevel(level Level) error {
+	if level < DebugLevel || level > FatalLevel {
+		return ErrInvalidLevel
+	}
+
+	r.level = level
+
+	return nil
+}
+
+// SetOutput does nothing, since the output destination is determined by the handler.
+func (r *SlogLogger) SetOutput(_ io.Writer) {}
+
+// SetShutdown sets the Shutdowner that terminates the application with the exit code on Fatal, e.g. a
+// terminator.Terminator. Nil exits immediately with FatalExitCode.
+func (r *SlogLogger) SetShutdown(shutdowner Shutdowner, exitCode int) {
+	if shutdowner == nil {
+		r.shutdown = nil
+		return
+	}
+
+	r.shutdown = &fatalShutdown{shutdowner: shutdowner, exitCode: exitCode}
+}
+
+// Warn writes warning level messages.
+func (r *SlogLogger) Warn(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, nil, v...)
+}
+
+// WarnContext writes warning level messages with the IDs stored in the context as fields, see ContextWithID.
+func (r *SlogLogger) WarnContext(ctx context.Context, v ...any) {
+	r.outputContext(ctx, WarnLevel, v)
+}
+
+// Warnf writes warning level messages using formatted string.
+func (r *SlogLogger) Warnf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, &format, v...)
+}
+
+// Warning writes warning level messages as an alias for the Warn method.
+func (r *SlogLogger) Warning(v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, nil, v...)
+}
+
+// Warningf writes warning level messages using a formatted string. It acts as an alias for the Warnf method.
+func (r *SlogLogger) Warningf(format string, v ...any) {
+	//nolint:mnd // Skips over this method in stack trace.
+	r.Output(WarnLevel, 2, &format, v...)
+}
+
+// With returns a child logger that adds the alternating keys and values to each record, see NewFields.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *SlogLogger) With(keyValues ...any) Logger {
+	return r.WithFields(NewFields(keyValues...))
+}
+
+// WithFields returns a child logger that adds the fields as attributes to each record.
+//
+//nolint:ireturn // Child loggers must satisfy the Logger interface.
+func (r *SlogLogger) WithFields(fields Fields) Logger {
+	child := *r
+	child.handler = r.handler.WithAttrs(fieldAttrs(fields))
+
+	return &child
+}
+
+// output passes a record to the handler like Output, but with the context, which is available to the handler.
+func (r *SlogLogger) output(ctx context.Context, level Level, calldepth int, format *string, messages []any) {
+	if r.Level() > level || !r.handler.Enabled(ctx, SlogLevel(level)) {
+		return
+	}
+
+	var message string
+	if format == nil {
+		message = fmt.Sprint(messages...)
+	} else {
+		message = fmt.Sprintf(*format, messages...)
+	}
+
+	// Skip runtime.Callers and this method to reach the frame at the calldepth.
+	var pcs [1]uintptr
+	runtime.Callers(calldepth+1, pcs[:])
+
+	_ = r.handler.Handle(ctx, slog.NewRecord(time.Now(), SlogLevel(level), message, pcs[0]))
+}
+
+// outputContext passes a record with the IDs stored in the context as attributes to the handler.
+func (r *SlogLogger) outputContext(ctx context.Context, level Level, messages []any) {
+	log := r
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		child := *r
+		child.handler = r.handler.WithAttrs(fieldAttrs(fields))
+		log = &child
+	}
+
+	//nolint:mnd // Skips over this method and the calling Context method in stack trace.
+	log.output(ctx, level, 3, nil, messages)
+}
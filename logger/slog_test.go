@@ -0,0 +1,122 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		level logger.Level
+		log   func(log *slog.Logger)
+		want  map[string]any
+	}{
+		{
+			name:  "attrs",
+			level: logger.InfoLevel,
+			log: func(log *slog.Logger) {
+				log.Info("processing", "jobID", "42", slog.Int("attempt", 2))
+			},
+			want: map[string]any{"level": "info", "message": "processing", "jobID": "42", "attempt": 2.0},
+		},
+		{
+			name:  "groups",
+			level: logger.InfoLevel,
+			log: func(log *slog.Logger) {
+				log.With("app", "test").WithGroup("request").
+					Warn("slow", "id", "abc", slog.Group("client", "ip", "127.0.0.1"), slog.Group("empty"))
+			},
+			want: map[string]any{
+				"level": "warn", "message": "slow", "app": "test",
+				"request.id": "abc", "request.client.ip": "127.0.0.1",
+			},
+		},
+		{
+			name:  "caller",
+			level: logger.DebugLevel,
+			log: func(log *slog.Logger) {
+				log.Debug("debugging")
+			},
+			want: map[string]any{"level": "debug", "message": "debugging", "file": "slog_test.go"},
+		},
+		{
+			name:  "disabled level",
+			level: logger.ErrorLevel,
+			log: func(log *slog.Logger) {
+				log.Warn("ignored")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(logger.WithFormat(logger.JSONFormat), logger.WithLevel(tt.level))
+			log.SetOutput(&buf)
+
+			tt.log(logger.NewSlog(log))
+
+			if tt.want == nil {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			for key, value := range tt.want {
+				assert.Equal(t, value, got[key], key)
+			}
+		})
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+	log := logger.NewSlogLogger(handler, logger.WithLevel(logger.DebugLevel))
+
+	log.With("jobID", "42").Warnf("job %s failed", "42")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "WARN", got["level"])
+	assert.Equal(t, "job 42 failed", got["msg"])
+	assert.Equal(t, "42", got["jobID"])
+
+	source, ok := got["source"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, source["file"], "slog_test.go")
+
+	buf.Reset()
+	require.NoError(t, log.SetLevel(logger.ErrorLevel))
+	log.Warn("ignored")
+	assert.Empty(t, buf.String())
+
+	require.ErrorIs(t, log.SetFormat(logger.JSONFormat), logger.ErrNotSupported)
+}
+
+func TestLevelFromSlog(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []logger.Level{
+		logger.DebugLevel, logger.InfoLevel, logger.WarnLevel, logger.ErrorLevel, logger.FatalLevel,
+	} {
+		assert.Equal(t, level, logger.LevelFromSlog(logger.SlogLevel(level)))
+	}
+
+	assert.Equal(t, logger.InfoLevel, logger.LevelFromSlog(slog.LevelInfo+2))
+	assert.Equal(t, logger.DebugLevel, logger.LevelFromSlog(slog.LevelDebug-4))
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxStackDepth is the maximum number of frames of a captured stack trace.
+	maxStackDepth = 32
+
+	// maxChainLength is the maximum number of errors of an error chain, which stops errors wrapping themselves.
+	maxChainLength = 32
+)
+
+// StackFrame is a function call of a stack trace.
+type StackFrame struct {
+	// Function is the fully qualified name of the function, e.g. "main.main".
+	Function string `json:"function"`
+
+	// File is the full path of the source code file.
+	File string `json:"file"`
+
+	// Line is the line number within the file.
+	Line int `json:"line"`
+}
+
+// ChainedError describes an error of an error chain.
+type ChainedError struct {
+	// Type is the Go type of the error, e.g. "*fs.PathError".
+	Type string `json:"type"`
+
+	// Message is the message of the error.
+	Message string `json:"message"`
+}
+
+// ErrorDetails holds the stack trace of the logging call and the chain of the logged errors of an ErrorLevel or
+// FatalLevel entry, see WithStackTrace.
+type ErrorDetails struct {
+	// Stack is the stack trace of the logging call, starting with the caller.
+	Stack []StackFrame
+
+	// Chain contains the errors passed as messages or fields and all errors they wrap, depth-first as unwrapped by
+	// errors.Unwrap and Unwrap() []error, e.g. of errors.Join.
+	Chain []ChainedError
+}
+
+// String returns the stack trace formatted like by the Go runtime, i.e. each function followed by its file and
+// line on an indented line.
+func (r *ErrorDetails) String() string {
+	var builder strings.Builder
+
+	for i, frame := range r.Stack {
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+
+		builder.WriteString(frame.Function)
+		builder.WriteString("\n\t")
+		builder.WriteString(frame.File)
+		builder.WriteByte(':')
+		builder.WriteString(strconv.Itoa(frame.Line))
+	}
+
+	return builder.String()
+}
+
+// newErrorDetails captures the stack trace at the calldepth, where 0 is the caller of newErrorDetails, and the
+// chains of the errors in the messages and the fields.
+func newErrorDetails(calldepth int, messages []any, fields Fields) *ErrorDetails {
+	details := &ErrorDetails{Stack: captureStack(calldepth + 1)}
+
+	for _, message := range messages {
+		if err, ok := message.(error); ok {
+			details.Chain = appendErrorChain(details.Chain, err)
+		}
+	}
+
+	for _, key := range fields.Keys() {
+		if err, ok := fields[key].(error); ok {
+			details.Chain = appendErrorChain(details.Chain, err)
+		}
+	}
+
+	return details
+}
+
+// captureStack returns the stack trace starting at the calldepth, where 0 is the caller of captureStack.
+func captureStack(calldepth int) []StackFrame {
+	pcs := make([]uintptr, maxStackDepth)
+	pcs = pcs[:runtime.Callers(calldepth+2, pcs)] //nolint:mnd // Skip the frames of runtime.Callers and captureStack.
+
+	stack := make([]StackFrame, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			stack = append(stack, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+// appendErrorChain appends the error and the errors it wraps depth-first to the chain.
+func appendErrorChain(chain []ChainedError, err error) []ChainedError {
+	if err == nil || len(chain) >= maxChainLength {
+		return chain
+	}
+
+	chain = append(chain, ChainedError{Type: fmt.Sprintf("%T", err), Message: err.Error()})
+
+	//nolint:errorlint // The wrapped errors are unwrapped one by one to build the chain.
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			chain = appendErrorChain(chain, wrapped)
+		}
+	default:
+		chain = appendErrorChain(chain, errors.Unwrap(err))
+	}
+
+	return chain
+}
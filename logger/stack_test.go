@@ -0,0 +1,127 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stackEntry struct {
+	Message string                `json:"message"`
+	Stack   []logger.StackFrame   `json:"error.stack"`
+	Chain   []logger.ChainedError `json:"error.chain"`
+}
+
+func TestDefaultLogger_StackTrace(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithFormat(logger.JSONFormat), logger.WithStackTrace(true))
+	log.SetOutput(&buf)
+
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	err := fmt.Errorf("failed to load: %w", errors.Join(pathErr, fs.ErrPermission))
+	log.Error(err)
+
+	var got stackEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.NotEmpty(t, got.Stack)
+	assert.True(t, strings.HasSuffix(got.Stack[0].Function, ".TestDefaultLogger_StackTrace"))
+	assert.True(t, strings.HasSuffix(got.Stack[0].File, "stack_test.go"))
+	assert.Positive(t, got.Stack[0].Line)
+
+	assert.Equal(t, []logger.ChainedError{
+		{Type: "*fmt.wrapError", Message: err.Error()},
+		{Type: "*errors.joinError", Message: "open config.yaml: file does not exist\npermission denied"},
+		{Type: "*fs.PathError", Message: "open config.yaml: file does not exist"},
+		{Type: "*errors.errorString", Message: "file does not exist"},
+		{Type: "*errors.errorString", Message: "permission denied"},
+	}, got.Chain)
+}
+
+func TestDefaultLogger_StackTraceLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		log       func(log logger.Logger)
+		wantStack bool
+		wantChain bool
+	}{
+		{"disabled", false, func(log logger.Logger) { log.Error(errors.New("failed")) }, false, false},
+		{"warn", true, func(log logger.Logger) { log.Warn(errors.New("failed")) }, false, false},
+		{"error without error", true, func(log logger.Logger) { log.Error("failed") }, true, false},
+		{"error field", true, func(log logger.Logger) { log.With("error", errors.New("failed")).Errorf("retry") }, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(logger.WithFormat(logger.JSONFormat), logger.WithStackTrace(tt.enabled))
+			log.SetOutput(&buf)
+
+			tt.log(log)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.wantStack, got["error.stack"] != nil)
+			assert.Equal(t, tt.wantChain, got["error.chain"] != nil)
+		})
+	}
+}
+
+func TestMultiLogger_StackTrace(t *testing.T) {
+	t.Parallel()
+
+	var ecs, gelf, plain bytes.Buffer
+	log := logger.NewMultiLogger([]*logger.Sink{
+		logger.NewSink(&ecs, logger.ECSFormat, logger.InfoLevel),
+		logger.NewSink(&gelf, logger.GELFFormat, logger.InfoLevel),
+		logger.NewSink(&plain, logger.PlainFormat, logger.InfoLevel),
+	})
+	logger.WithStackTrace(true)(log)
+
+	log.Error(fs.ErrNotExist)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(ecs.Bytes(), &got))
+	assert.Contains(t, got["error.stack_trace"], ".TestMultiLogger_StackTrace\n\t")
+	assert.Equal(t, "*errors.errorString", got["error.type"])
+
+	require.NoError(t, json.Unmarshal(gelf.Bytes(), &got))
+	assert.Contains(t, got["full_message"], ".TestMultiLogger_StackTrace\n\t")
+
+	assert.NotContains(t, plain.String(), "TestMultiLogger_StackTrace")
+}
+
+func TestRedactor_RedactEntryChain(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	cfg := logger.Config{}
+	cfg.SetDefaults()
+	cfg.Format = logger.JSONFormat
+	cfg.StackTrace = true
+
+	log := logger.New(logger.WithConfig(&cfg))
+	log.SetOutput(&buf)
+
+	log.Error(fmt.Errorf("failed to notify: %w", errors.New("invalid address bob@example.com")))
+
+	var got stackEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got.Chain, 2)
+	assert.Equal(t, "invalid address [REDACTED]", got.Chain[1].Message)
+	assert.NotContains(t, buf.String(), "bob@example.com")
+}
@@ -0,0 +1,335 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// SyslogNetworkUDP sends each message in a datagram (RFC 5426).
+	SyslogNetworkUDP = "udp"
+
+	// SyslogNetworkTCP sends the messages with octet-counting framing (RFC 6587).
+	SyslogNetworkTCP = "tcp"
+
+	// SyslogNetworkTLS sends the messages with octet-counting framing over TLS (RFC 5425).
+	SyslogNetworkTLS = "tcp+tls"
+
+	// DefaultSyslogBufferSize is the number of messages kept in memory while the connection is down.
+	DefaultSyslogBufferSize = 1024
+
+	// DefaultSyslogMinBackoff is the initial delay between reconnection attempts.
+	DefaultSyslogMinBackoff = 100 * time.Millisecond
+
+	// DefaultSyslogMaxBackoff is the maximum delay between reconnection attempts.
+	DefaultSyslogMaxBackoff = 30 * time.Second
+
+	// syslogTimeout limits the time to connect and to send a message.
+	syslogTimeout = 5 * time.Second
+
+	// syslogFlushInterval is the interval in which Flush checks whether the queue is empty.
+	syslogFlushInterval = 10 * time.Millisecond
+)
+
+// SyslogOption is a functional option that can be applied to a SyslogWriter.
+type SyslogOption func(*SyslogWriter)
+
+// WithSyslogTLSConfig sets the TLS configuration of the SyslogNetworkTLS network, e.g. to trust a private CA.
+func WithSyslogTLSConfig(cfg *tls.Config) SyslogOption {
+	return func(w *SyslogWriter) {
+		w.tlsConfig = cfg
+	}
+}
+
+// WithSyslogBufferSize sets the number of messages kept in memory while the connection is down.
+func WithSyslogBufferSize(size int) SyslogOption {
+	return func(w *SyslogWriter) {
+		if size > 0 {
+			w.bufferSize = size
+		}
+	}
+}
+
+// WithSyslogBackoff sets the initial and the maximum delay between reconnection attempts.
+func WithSyslogBackoff(minBackoff, maxBackoff time.Duration) SyslogOption {
+	return func(w *SyslogWriter) {
+		if minBackoff > 0 && maxBackoff >= minBackoff {
+			w.minBackoff, w.maxBackoff = minBackoff, maxBackoff
+		}
+	}
+}
+
+// SyslogWriter is an io.WriteCloser that sends each write as a message to a remote syslog server, e.g. rsyslog.
+// Messages are queued and sent in the background, so logging doesn't block while the server is unreachable.
+// The connection is reestablished with exponential backoff; if the queue is full, the oldest message is dropped.
+type SyslogWriter struct {
+	network    string
+	address    string
+	tlsConfig  *tls.Config
+	bufferSize int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mutex    sync.Mutex
+	queue    []syslogMessage
+	sequence uint64
+	closed   bool
+	dropped  atomic.Uint64
+
+	notify  chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewSyslogWriter creates a SyslogWriter for an address like "tcp+tls://host:6514", "tcp://host:514" or
+// "udp://host:514" and starts sending in the background.
+func NewSyslogWriter(address string, opts ...SyslogOption) (*SyslogWriter, error) {
+	network, host, err := parseSyslogAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &SyslogWriter{
+		network:    network,
+		address:    host,
+		bufferSize: DefaultSyslogBufferSize,
+		minBackoff: DefaultSyslogMinBackoff,
+		maxBackoff: DefaultSyslogMaxBackoff,
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(writer)
+	}
+
+	if writer.network == SyslogNetworkTLS && writer.tlsConfig == nil {
+		hostname, _, _ := net.SplitHostPort(host)
+		writer.tlsConfig = &tls.Config{ServerName: hostname, MinVersion: tls.VersionTLS12}
+	}
+
+	go writer.run()
+
+	return writer, nil
+}
+
+// Close stops accepting messages, sends the queued ones while the connection is up, and closes the connection.
+func (r *SyslogWriter) Close() error {
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return nil
+	}
+	r.closed = true
+	r.mutex.Unlock()
+
+	close(r.done)
+	<-r.stopped
+
+	return nil
+}
+
+// Dropped returns the number of messages dropped because the queue was full.
+func (r *SyslogWriter) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Flush blocks until the queued messages are sent or the context is done, e.g. while the server is unreachable.
+func (r *SyslogWriter) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(syslogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, ok := r.peek(); !ok {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stopped:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("failed to flush syslog messages: %w", ctx.Err())
+		}
+	}
+}
+
+// Write queues the data, without a trailing newline, as a message. It never blocks on the network.
+func (r *SyslogWriter) Write(data []byte) (int, error) {
+	message := bytes.Clone(bytes.TrimSuffix(data, []byte{'\n'}))
+
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return 0, os.ErrClosed
+	}
+
+	if len(r.queue) >= r.bufferSize {
+		r.queue = r.queue[1:]
+		r.dropped.Add(1)
+	}
+	r.sequence++
+	r.queue = append(r.queue, syslogMessage{sequence: r.sequence, data: message})
+	r.mutex.Unlock()
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+
+	return len(data), nil
+}
+
+// dial connects to the syslog server.
+func (r *SyslogWriter) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if r.network == SyslogNetworkTLS {
+		conn, err = tls.DialWithDialer(dialer, SyslogNetworkTCP, r.address, r.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(r.network, r.address)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog server: %w", err)
+	}
+
+	return conn, nil
+}
+
+// peek returns the oldest queued message without removing it.
+func (r *SyslogWriter) peek() (syslogMessage, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.queue) == 0 {
+		return syslogMessage{}, false
+	}
+
+	return r.queue[0], true
+}
+
+// pop removes the sent message from the queue, unless it was dropped in the meantime.
+func (r *SyslogWriter) pop(message syslogMessage) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.queue) > 0 && r.queue[0].sequence == message.sequence {
+		r.queue = r.queue[1:]
+	}
+}
+
+// run sends the queued messages until the writer is closed. Failed messages are retried after reconnecting.
+func (r *SyslogWriter) run() {
+	defer close(r.stopped)
+
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	backoff := r.minBackoff
+
+	for {
+		message, ok := r.peek()
+		if !ok {
+			select {
+			case <-r.notify:
+				continue
+			case <-r.done:
+				return
+			}
+		}
+
+		if conn == nil {
+			var err error
+			if conn, err = r.dial(); err != nil {
+				select {
+				case <-time.After(backoff):
+				case <-r.done:
+					return
+				}
+				backoff = min(backoff*2, r.maxBackoff) //nolint:mnd // Doubles the delay.
+				continue
+			}
+			backoff = r.minBackoff
+		}
+
+		if err := r.send(conn, message.data); err != nil {
+			_ = conn.Close()
+			conn = nil
+			continue
+		}
+
+		r.pop(message)
+	}
+}
+
+// send writes the message to the connection, framed by its length for stream networks.
+func (r *SyslogWriter) send(conn net.Conn, message []byte) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return fmt.Errorf("failed to send syslog message: %w", err)
+	}
+
+	frame := message
+	if r.network != SyslogNetworkUDP {
+		frame = append([]byte(strconv.Itoa(len(message))+" "), message...)
+	}
+
+	if _, err := conn.Write(frame); err != nil {
+		return fmt.Errorf("failed to send syslog message: %w", err)
+	}
+
+	return nil
+}
+
+// syslogMessage is a queued message with a sequence number identifying it.
+type syslogMessage struct {
+	sequence uint64
+	data     []byte
+}
+
+// isSyslogAddress reports whether the output is the address of a remote syslog server.
+func isSyslogAddress(output string) bool {
+	return strings.HasPrefix(output, SyslogNetworkUDP+"://") ||
+		strings.HasPrefix(output, SyslogNetworkTCP+"://") ||
+		strings.HasPrefix(output, SyslogNetworkTLS+"://")
+}
+
+// parseSyslogAddress returns the network and the host with port of an address like "tcp+tls://host:6514".
+func parseSyslogAddress(address string) (string, string, error) {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+
+	switch parsed.Scheme {
+	case SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkTLS:
+	default:
+		return "", "", fmt.Errorf("%w: unsupported network %q", ErrInvalidOutput, parsed.Scheme)
+	}
+
+	if parsed.Hostname() == "" || parsed.Port() == "" {
+		return "", "", fmt.Errorf("%w: %q requires a host and a port", ErrInvalidOutput, address)
+	}
+
+	return parsed.Scheme, parsed.Host, nil
+}
@@ -0,0 +1,232 @@
+package logger_test
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spacecafe/gobox/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readFrames reads octet-counted syslog frames from the connection and sends their messages to the channel.
+func readFrames(conn net.Conn, messages chan<- string) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+
+		size, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+		if err != nil {
+			return
+		}
+
+		message := make([]byte, size)
+		if _, err = io.ReadFull(reader, message); err != nil {
+			return
+		}
+		messages <- string(message)
+	}
+}
+
+// serveFrames accepts connections on the listener and reads their frames.
+func serveFrames(listener net.Listener, messages chan<- string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go readFrames(conn, messages)
+	}
+}
+
+// selfSignedTLSConfigs returns the TLS configuration of a server with a self-signed certificate for 127.0.0.1
+// and the configuration of a client trusting it.
+func selfSignedTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "syslog"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	server := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+	client := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	return server, client
+}
+
+func TestSyslogWriter_Stream(t *testing.T) {
+	t.Parallel()
+
+	serverTLS, clientTLS := selfSignedTLSConfigs(t)
+
+	tests := []struct {
+		name    string
+		network string
+		listen  func(t *testing.T) net.Listener
+		opts    []logger.SyslogOption
+	}{
+		{
+			name:    "tcp",
+			network: logger.SyslogNetworkTCP,
+			listen: func(t *testing.T) net.Listener {
+				t.Helper()
+
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				require.NoError(t, err)
+
+				return listener
+			},
+		},
+		{
+			name:    "tls",
+			network: logger.SyslogNetworkTLS,
+			listen: func(t *testing.T) net.Listener {
+				t.Helper()
+
+				listener, err := tls.Listen("tcp", "127.0.0.1:0", serverTLS)
+				require.NoError(t, err)
+
+				return listener
+			},
+			opts: []logger.SyslogOption{logger.WithSyslogTLSConfig(clientTLS)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			listener := tt.listen(t)
+			defer listener.Close()
+
+			messages := make(chan string, 10)
+			go serveFrames(listener, messages)
+
+			writer, err := logger.NewSyslogWriter(tt.network+"://"+listener.Addr().String(), tt.opts...)
+			require.NoError(t, err)
+
+			log := logger.New(logger.WithFormat(logger.SyslogFormat))
+			log.SetOutput(writer)
+			log.With("jobID", "42").Info("first message")
+			log.Info("second message")
+
+			for _, want := range []string{"first message", "second message"} {
+				select {
+				case message := <-messages:
+					assert.True(t, strings.HasPrefix(message, "<134>1 "), message)
+					assert.True(t, strings.HasSuffix(message, want), message)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("message %q was not received", want)
+				}
+			}
+
+			require.NoError(t, writer.Close())
+		})
+	}
+}
+
+func TestSyslogWriter_UDP(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	writer, err := logger.NewSyslogWriter("udp://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("<134>1 - - app - - - datagram\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buffer := make([]byte, 1024)
+	size, _, err := conn.ReadFrom(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "<134>1 - - app - - - datagram", string(buffer[:size]))
+}
+
+func TestSyslogWriter_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	// Reserve an address and release it, so the writer cannot connect at first.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	writer, err := logger.NewSyslogWriter("tcp://"+address,
+		logger.WithSyslogBufferSize(2),
+		logger.WithSyslogBackoff(10*time.Millisecond, 50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	for i := range 3 {
+		_, err = writer.Write([]byte("message " + strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, uint64(1), writer.Dropped())
+
+	listener, err = net.Listen("tcp", address)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	messages := make(chan string, 10)
+	go serveFrames(listener, messages)
+
+	for _, want := range []string{"message 1", "message 2"} {
+		select {
+		case message := <-messages:
+			assert.Equal(t, want, message)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("message %q was not received", want)
+		}
+	}
+}
+
+func TestNewSyslogWriter_InvalidAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, address := range []string{"http://localhost:514", "tcp://localhost", "udp://:514"} {
+		_, err := logger.NewSyslogWriter(address)
+		require.ErrorIs(t, err, logger.ErrInvalidOutput, address)
+	}
+}
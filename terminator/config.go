@@ -13,6 +13,10 @@ type Config struct {
 	// Timeout specifies the duration before the application is forcefully killed.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout" yaml:"timeout"`
 
+	// FlushTimeout limits the time to write buffered log entries of the default logger before terminating,
+	// see logger.Flush. Zero disables flushing.
+	FlushTimeout time.Duration `json:"flushTimeout" mapstructure:"flushTimeout" yaml:"flushTimeout"`
+
 	// Force indicates whether to forcibly terminate the application without waiting for a graceful shutdown.
 	Force bool `json:"force" mapstructure:"force" yaml:"force"`
 }
@@ -20,6 +24,7 @@ type Config struct {
 // SetDefaults initializes the default values for the relevant fields in the struct.
 func (r *Config) SetDefaults() {
 	r.Timeout = time.Second * 3 //nolint:mnd // Default timeout value
+	r.FlushTimeout = time.Second
 	r.Force = true
 }
 
@@ -29,5 +34,9 @@ func (r *Config) Validate() error {
 		return ErrInvalidTimeout
 	}
 
+	if r.FlushTimeout < 0 {
+		return ErrInvalidFlushTimeout
+	}
+
 	return nil
 }
@@ -4,4 +4,7 @@ import (
 	"errors"
 )
 
-var ErrInvalidTimeout = errors.New("terminator timeout must be greater than 0")
+var (
+	ErrInvalidTimeout      = errors.New("terminator timeout must be greater than 0")
+	ErrInvalidFlushTimeout = errors.New("terminator flush timeout must not be negative")
+)
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/spacecafe/gobox/logger"
 )
 
 const (
@@ -20,6 +22,8 @@ const (
 //nolint:gochecknoglobals // This is a mock for os.Exit used in tests to prevent actual program termination
 var OsExit = os.Exit
 
+var _ logger.Shutdowner = (*Terminator)(nil)
+
 // Terminator is a struct that manages context cancellation and synchronization.
 type Terminator struct {
 	// ctx is the context for managing cancellation.
@@ -38,6 +42,9 @@ type Terminator struct {
 	signalCh chan os.Signal
 
 	doneCh chan struct{}
+
+	// stopOnce ensures that the shutdown runs once, even if it's triggered by a signal and Shutdown.
+	stopOnce sync.Once
 }
 
 // New creates a new Terminator instance with the provided configuration.
@@ -77,6 +84,15 @@ func (r *Terminator) Go(task func()) {
 	}()
 }
 
+// Shutdown terminates the application with the exit code like a termination signal, but regardless of
+// Config.Force: the context is canceled, the tracked goroutines are awaited for up to Config.Timeout and the logs
+// are flushed. It implements logger.Shutdowner, so Fatal of a logger can shut down gracefully, see
+// logger.WithShutdown.
+func (r *Terminator) Shutdown(exitCode int) {
+	r.stop()
+	OsExit(exitCode)
+}
+
 // Track increments the waitGroup by one without returning the context.
 // Therefore, the application is terminated after Config.Timeout.
 func (r *Terminator) Track() {
@@ -108,20 +124,42 @@ func (r *Terminator) Wait() {
 // awaitSignal waits for interrupt or termination signals and handles them.
 func (r *Terminator) awaitSignal() {
 	<-r.signalCh
-	r.cancelFn()
-
-	// Guarantee termination after the specified timeout.
-	go func() {
-		r.waitGroup.Wait()
-		close(r.doneCh)
-	}()
-
-	select {
-	case <-r.doneCh:
-	case <-time.After(r.cfg.Timeout):
-	}
+	r.stop()
 
 	if r.cfg.Force {
 		OsExit(ExitCodeSigTerm)
 	}
 }
+
+// flushLogs writes the buffered entries of the default logger, but gives up after Config.FlushTimeout.
+func (r *Terminator) flushLogs() {
+	if r.cfg.FlushTimeout <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.FlushTimeout)
+	defer cancel()
+
+	_ = logger.Flush(ctx)
+}
+
+// stop cancels the context, waits until the tracked goroutines are finished or Config.Timeout elapsed and flushes
+// the logs. Only the first call stops, later ones block until it's done.
+func (r *Terminator) stop() {
+	r.stopOnce.Do(func() {
+		r.cancelFn()
+
+		// Guarantee termination after the specified timeout.
+		go func() {
+			r.waitGroup.Wait()
+			close(r.doneCh)
+		}()
+
+		select {
+		case <-r.doneCh:
+		case <-time.After(r.cfg.Timeout):
+		}
+
+		r.flushLogs()
+	})
+}
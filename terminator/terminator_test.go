@@ -1,17 +1,44 @@
 package terminator_test
 
 import (
+	"bytes"
 	"context"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/spacecafe/gobox/logger"
 	"github.com/spacecafe/gobox/terminator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// slowWriter simulates a slow log output destination.
+type slowWriter struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (r *slowWriter) Write(data []byte) (int, error) {
+	time.Sleep(20 * time.Millisecond)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.buffer.Write(data)
+}
+
+func (r *slowWriter) String() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.buffer.String()
+}
+
 func sendSigTerm(t *testing.T) {
 	t.Helper()
 
@@ -25,17 +52,17 @@ func sendSigTerm(t *testing.T) {
 //nolint:paralleltest // This test is not safe to run in parallel.
 func TestWithoutTracking(t *testing.T) {
 	t.Run("", func(t *testing.T) {
-		_ = terminator.New(&terminator.Config{
-			Timeout: time.Second,
-			Force:   true,
-		})
-
-		// Mock os.Exit to prevent the test from exiting.
+		// Mock os.Exit to prevent the test from exiting, before the terminator starts listening to signals.
 		exitCh := make(chan int)
 		terminator.OsExit = func(code int) {
 			exitCh <- code
 		}
 
+		_ = terminator.New(&terminator.Config{
+			Timeout: time.Second,
+			Force:   true,
+		})
+
 		sendSigTerm(t)
 
 		// Wait for the osExit to be called.
@@ -53,17 +80,17 @@ func TestWithTracking(t *testing.T) {
 	cfg := &terminator.Config{}
 	cfg.SetDefaults()
 	t.Run("", func(t *testing.T) {
-		term := terminator.New(&terminator.Config{
-			Timeout: time.Second,
-			Force:   true,
-		})
-
-		// Mock os.Exit to prevent the test from exiting.
+		// Mock os.Exit to prevent the test from exiting, before the terminator starts listening to signals.
 		exitCh := make(chan int)
 		terminator.OsExit = func(code int) {
 			exitCh <- code
 		}
 
+		term := terminator.New(&terminator.Config{
+			Timeout: time.Second,
+			Force:   true,
+		})
+
 		go func(ctx context.Context, done func()) {
 			<-ctx.Done()
 			<-time.After(time.Second)
@@ -81,3 +108,94 @@ func TestWithTracking(t *testing.T) {
 		}
 	})
 }
+
+//nolint:paralleltest // This test is not safe to run in parallel.
+func TestFlushesLogs(t *testing.T) {
+	writer := &slowWriter{}
+	log := logger.New(logger.WithAsync(16, logger.BlockPolicy))
+	log.SetOutput(writer)
+
+	previous := logger.Default()
+	logger.SetDefault(log)
+	t.Cleanup(func() {
+		logger.SetDefault(previous)
+	})
+
+	exitCh := make(chan int)
+	terminator.OsExit = func(code int) {
+		exitCh <- code
+	}
+
+	_ = terminator.New(&terminator.Config{
+		Timeout:      time.Second,
+		FlushTimeout: time.Second,
+		Force:        true,
+	})
+
+	for range 10 {
+		logger.Info("shutting down")
+	}
+
+	sendSigTerm(t)
+
+	select {
+	case code := <-exitCh:
+		assert.Equal(t, terminator.ExitCodeSigTerm, code)
+		assert.Equal(t, 10, strings.Count(writer.String(), "shutting down"))
+	case <-time.After(4 * time.Second):
+		t.Fatal("Timeout waiting for os.Exit to be called")
+	}
+}
+
+//nolint:paralleltest // This test is not safe to run in parallel.
+func TestShutdown(t *testing.T) {
+	previousTerminator, previousLogger := terminator.OsExit, logger.OsExit
+	t.Cleanup(func() {
+		terminator.OsExit, logger.OsExit = previousTerminator, previousLogger
+	})
+
+	// The mocked exit stops the goroutine of Fatal like os.Exit, so it doesn't continue after the first exit.
+	exitCh := make(chan int, 1)
+	exit := func(code int) {
+		exitCh <- code
+		runtime.Goexit()
+	}
+	terminator.OsExit = exit
+	logger.OsExit = exit
+
+	// Force doesn't apply to Shutdown.
+	term := terminator.New(&terminator.Config{
+		Timeout: time.Second,
+	})
+
+	var stopped bool
+	term.Go(func() {
+		<-term.Context().Done()
+		stopped = true
+	})
+
+	var buf bytes.Buffer
+	log := logger.New(logger.WithShutdown(term, 2))
+	log.SetOutput(&buf)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		log.Fatal("failed to listen") //nolint:revive // OsExit is mocked.
+	}()
+
+	select {
+	case code := <-exitCh:
+		assert.Equal(t, 2, code)
+		assert.True(t, stopped)
+		assert.Contains(t, buf.String(), "failed to listen")
+	case <-time.After(4 * time.Second):
+		t.Fatal("Timeout waiting for os.Exit to be called")
+	}
+
+	<-done
+	assert.Empty(t, exitCh)
+
+	term.Wait()
+}